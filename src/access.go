@@ -0,0 +1,364 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// Effective privileges are resolved from the collected grants and the role graph,
+// so they work on snapshots too. A role's privileges are active when every grant
+// on the path to it is inherited (PostgreSQL INHERIT; MySQL default roles or
+// activate_all_roles_on_login); other roles are reachable only with SET ROLE.
+
+// writePrivileges are the privileges that allow modifying the rows of a table
+var writePrivileges = map[string]bool{
+	"INSERT":         true,
+	"UPDATE":         true,
+	"DELETE":         true,
+	"TRUNCATE":       true,
+	"ALL PRIVILEGES": true,
+}
+
+// ResolveAccess computes the effective privileges of each login user and the
+// users that can write to each table
+func ResolveAccess(info *DatabaseInfo) *AccessReport {
+	postgres := info.DBType == "postgres"
+
+	// Privileges granted directly to each user or role
+	granted := make(map[string][]EffectivePrivilege)
+	superRoles := make(map[string]bool)
+	if postgres {
+		for _, priv := range info.Privileges {
+			// Default privileges apply to objects created later; the privileges
+			// every role has by default are left out
+			if strings.HasPrefix(priv.ObjectType, "DEFAULT ") || (priv.Grantee == "PUBLIC" && isDefaultPublicPrivilege(priv)) {
+				continue
+			}
+			granted[priv.Grantee] = append(granted[priv.Grantee], EffectivePrivilege{
+				Database:   priv.Database,
+				ObjectType: priv.ObjectType,
+				Object:     priv.Object,
+				Column:     priv.Column,
+				Privilege:  priv.Privilege,
+			})
+		}
+		for _, role := range info.Roles {
+			if role.IsSuperuser {
+				superRoles[role.RoleName] = true
+			}
+		}
+	} else {
+		for _, user := range info.Users {
+			granted[user.User+"@"+user.Host] = parseMySQLGrants(user.Grants)
+		}
+		for _, role := range info.Roles {
+			granted[role.RoleName+"@"+role.RoleHost] = parseMySQLGrants(role.Grants)
+		}
+	}
+
+	graph := info.RoleGraph
+	if graph == nil {
+		graph = &RoleGraph{}
+	}
+	edges := make(map[string][]RoleEdge)
+	for _, edge := range graph.Edges {
+		edges[edge.Member] = append(edges[edge.Member], edge)
+	}
+
+	isRole := make(map[string]bool)
+	for _, role := range info.Roles {
+		isRole[role.RoleName+"@"+role.RoleHost] = true
+	}
+
+	report := &AccessReport{Users: []UserAccess{}}
+	for _, user := range info.Users {
+		name := user.User
+		if !postgres {
+			// MySQL roles are locked accounts and are listed as users too
+			if mysqlSystemAccounts[user.User] || isRole[user.User+"@"+user.Host] {
+				continue
+			}
+			name = user.User + "@" + user.Host
+		}
+
+		userEdges := edges
+		if len(graph.MandatoryRoles) > 0 {
+			userEdges = make(map[string][]RoleEdge, len(edges)+1)
+			for member, memberEdges := range edges {
+				userEdges[member] = memberEdges
+			}
+			userEdges[name] = append([]RoleEdge(nil), edges[name]...)
+			for _, role := range graph.MandatoryRoles {
+				userEdges[name] = append(userEdges[name], RoleEdge{Role: role, Member: name, Inherit: true})
+			}
+		}
+
+		access := UserAccess{
+			User:      name,
+			Superuser: user.IsSuperuser,
+			Roles:     resolveRoles(name, userEdges, graph, postgres),
+		}
+
+		seen := make(map[EffectivePrivilege]bool)
+		add := func(privileges []EffectivePrivilege, via string, setRole bool) {
+			for _, priv := range privileges {
+				if seen[priv] {
+					continue
+				}
+				seen[priv] = true
+				priv.Via = via
+				priv.SetRole = setRole
+				access.Privileges = append(access.Privileges, priv)
+			}
+		}
+		add(granted[name], "", false)
+		if postgres {
+			add(granted["PUBLIC"], "PUBLIC", false)
+		}
+		for _, role := range access.Roles {
+			add(granted[role.Role], role.Role, role.SetRole)
+		}
+
+		report.Users = append(report.Users, access)
+	}
+
+	report.Writers = resolveWriters(info, report.Users, superRoles)
+	return report
+}
+
+// resolveRoles walks the role graph from user, first along inherited grants
+// only and then along grants that allow SET ROLE, so that each role is reported
+// with the shortest path that makes it active, or else needs SET ROLE
+func resolveRoles(user string, edges map[string][]RoleEdge, graph *RoleGraph, postgres bool) []RoleAccess {
+	var roles []RoleAccess
+	found := make(map[string]bool)
+
+	for _, setRole := range []bool{false, true} {
+		type step struct {
+			member string
+			path   []string
+		}
+		visited := map[string]bool{user: true}
+		queue := []step{{member: user}}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, edge := range edges[current.member] {
+				if visited[edge.Role] {
+					continue
+				}
+				active := edge.Inherit
+				if !postgres && current.member == user {
+					active = edge.Default || graph.ActivateAllRoles
+				}
+				if !active && !setRole || setRole && edge.NoSet {
+					continue
+				}
+				visited[edge.Role] = true
+				path := append(append([]string(nil), current.path...), edge.Role)
+				if !found[edge.Role] {
+					found[edge.Role] = true
+					roles = append(roles, RoleAccess{Role: edge.Role, Path: path, SetRole: setRole})
+				}
+				queue = append(queue, step{member: edge.Role, path: path})
+			}
+		}
+	}
+	return roles
+}
+
+// resolveWriters lists, for each base table, the users that can insert, update or delete its rows
+func resolveWriters(info *DatabaseInfo, users []UserAccess, superRoles map[string]bool) []TableWriters {
+	postgres := info.DBType == "postgres"
+	withDB := postgres && spansDatabases(info.Tables)
+
+	var writers []TableWriters
+	for _, table := range info.Tables {
+		if table.Type != "BASE TABLE" {
+			continue
+		}
+		name := table.Schema + "." + table.Name
+		if withDB {
+			name = qualifiedName(table.Database, table.Schema, table.Name)
+		}
+
+		entry := TableWriters{Table: name, Writers: []TableWriter{}}
+		for _, user := range users {
+			if user.Superuser {
+				entry.Writers = append(entry.Writers, TableWriter{User: user.User, Privileges: []string{"ALL"}, Via: "superuser"})
+				continue
+			}
+			if postgres && table.Owner == user.User {
+				entry.Writers = append(entry.Writers, TableWriter{User: user.User, Privileges: []string{"ALL"}, Via: "owner"})
+				continue
+			}
+
+			type source struct {
+				via     string
+				setRole bool
+			}
+			var sources []source
+			privsBySource := make(map[source][]string)
+			addSource := func(src source, privileges ...string) {
+				if _, ok := privsBySource[src]; !ok {
+					sources = append(sources, src)
+				}
+				privsBySource[src] = append(privsBySource[src], privileges...)
+			}
+
+			if postgres {
+				for _, role := range user.Roles {
+					switch {
+					case superRoles[role.Role]:
+						// The SUPERUSER attribute is not inherited; it needs SET ROLE
+						addSource(source{role.Role, true}, "ALL")
+					case table.Owner == role.Role:
+						addSource(source{role.Role, role.SetRole}, "ALL")
+					case role.Role == "pg_write_all_data":
+						addSource(source{role.Role, role.SetRole}, "INSERT", "UPDATE", "DELETE")
+					}
+				}
+			}
+			for _, priv := range user.Privileges {
+				if !writePrivileges[priv.Privilege] || !privilegeCoversTable(priv, table, postgres) {
+					continue
+				}
+				privilege := priv.Privilege
+				if priv.Column != "" {
+					privilege += " (" + priv.Column + ")"
+				}
+				addSource(source{priv.Via, priv.SetRole}, privilege)
+			}
+
+			for _, src := range sources {
+				entry.Writers = append(entry.Writers, TableWriter{
+					User:       user.User,
+					Privileges: uniqueSorted(privsBySource[src]),
+					Via:        src.via,
+					SetRole:    src.setRole,
+				})
+			}
+		}
+		writers = append(writers, entry)
+	}
+	return writers
+}
+
+// privilegeCoversTable reports whether a privilege applies to the rows of table
+func privilegeCoversTable(priv EffectivePrivilege, table TableInfo, postgres bool) bool {
+	if postgres {
+		return priv.ObjectType == "TABLE" &&
+			(priv.Database == "" || priv.Database == table.Database) &&
+			unquoteQualified(priv.Object) == table.Schema+"."+table.Name
+	}
+	switch priv.ObjectType {
+	case "GLOBAL":
+		return true
+	case "DATABASE":
+		return mysqlDatabaseMatches(priv.Object, table.Schema)
+	case "TABLE":
+		return priv.Object == table.Schema+"."+table.Name
+	}
+	return false
+}
+
+// parseMySQLGrants parses SHOW GRANTS lines into privileges. Role grants
+// (GRANT `r`@`%` TO ...), PROXY and USAGE carry no privileges and are skipped.
+func parseMySQLGrants(grants []string) []EffectivePrivilege {
+	var privileges []EffectivePrivilege
+	for _, grant := range grants {
+		rest, ok := strings.CutPrefix(grant, "GRANT ")
+		if !ok {
+			continue
+		}
+		on := indexTopLevel(rest, " ON ")
+		if on < 0 {
+			continue
+		}
+		level := rest[on+len(" ON "):]
+		if to := indexTopLevel(level, " TO "); to >= 0 {
+			level = level[:to]
+		}
+
+		objectType := ""
+		for _, kind := range []string{"PROCEDURE ", "FUNCTION ", "TABLE "} {
+			if trimmed, ok := strings.CutPrefix(level, kind); ok {
+				objectType, level = strings.TrimSpace(kind), trimmed
+			}
+		}
+		object := unquoteQualified(strings.TrimSpace(level))
+		switch {
+		case objectType != "":
+		case object == "*.*":
+			objectType = "GLOBAL"
+		case strings.HasSuffix(object, ".*"):
+			objectType, object = "DATABASE", strings.TrimSuffix(object, ".*")
+		default:
+			objectType = "TABLE"
+		}
+
+		for _, part := range splitTopLevel(rest[:on], ',') {
+			part = strings.TrimSpace(part)
+			name := part
+			var columns []string
+			if i := strings.Index(part, "("); i >= 0 {
+				name = strings.TrimSpace(part[:i])
+				for _, column := range splitTopLevel(parenContent(part), ',') {
+					columns = append(columns, unquoteIdent(strings.TrimSpace(column)))
+				}
+			}
+			name = strings.ToUpper(name)
+			if name == "" || name == "USAGE" || name == "PROXY" {
+				continue
+			}
+			if len(columns) == 0 {
+				columns = []string{""}
+			}
+			for _, column := range columns {
+				privileges = append(privileges, EffectivePrivilege{
+					ObjectType: objectType,
+					Object:     object,
+					Column:     column,
+					Privilege:  name,
+				})
+			}
+		}
+	}
+	return privileges
+}
+
+// mysqlDatabaseMatches matches a database name against a database-level grant,
+// where % and _ are wildcards unless escaped with a backslash
+func mysqlDatabaseMatches(pattern, database string) bool {
+	if pattern == "" {
+		return database == ""
+	}
+	switch c := pattern[0]; {
+	case c == '%':
+		for i := 0; i <= len(database); i++ {
+			if mysqlDatabaseMatches(pattern[1:], database[i:]) {
+				return true
+			}
+		}
+		return false
+	case c == '_':
+		return database != "" && mysqlDatabaseMatches(pattern[1:], database[1:])
+	case c == '\\' && len(pattern) > 1:
+		return database != "" && database[0] == pattern[1] && mysqlDatabaseMatches(pattern[2:], database[1:])
+	default:
+		return database != "" && database[0] == c && mysqlDatabaseMatches(pattern[1:], database[1:])
+	}
+}
+
+func uniqueSorted(values []string) []string {
+	set := make(map[string]bool)
+	var unique []string
+	for _, v := range values {
+		if !set[v] {
+			set[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
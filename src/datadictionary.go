@@ -0,0 +1,331 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The data dictionary is the collected schema written for readers who do not
+// read DDL, such as analysts: each table and view with its description, and
+// each column with its type, nullability, default, key and description.
+// It is written with -data-dictionary instead of the report.
+
+// DataDictionaryFormatter formats the tables, views, types and routines as a Markdown data dictionary
+type DataDictionaryFormatter struct{}
+
+// dictionaryObjectTypes are the labels of the tables in the dictionary; other table types are left out
+var dictionaryObjectTypes = map[string]string{
+	"BASE TABLE":        "Table",
+	"VIEW":              "View",
+	"MATERIALIZED VIEW": "Materialized view",
+	"FOREIGN TABLE":     "Foreign table",
+}
+
+// dictionaryGroup is a MySQL database or a PostgreSQL schema, with its objects sorted by name
+type dictionaryGroup struct {
+	label   string
+	comment string
+	tables  []TableInfo
+}
+
+func (f *DataDictionaryFormatter) Format(info *DatabaseInfo) (string, error) {
+	var result strings.Builder
+
+	dbLabel := "MySQL"
+	groupLabel := "Database"
+	if info.DBType == "postgres" {
+		dbLabel = "PostgreSQL"
+		groupLabel = "Schema"
+	}
+
+	// PostgreSQL names need the database when several databases are collected
+	withDB := info.DBType == "postgres" && spansDatabases(info.Tables)
+	groups := f.groups(info, withDB)
+	var objects, columns, described int
+	for _, group := range groups {
+		for _, table := range group.tables {
+			objects++
+			for _, column := range f.columns(table) {
+				columns++
+				if column.Comment != "" {
+					described++
+				}
+			}
+		}
+	}
+
+	result.WriteString("# Data Dictionary\n\n")
+	if info.ConnectionInfo != nil {
+		result.WriteString(fmt.Sprintf("**Database**: %s %s  \n", dbLabel, info.ConnectionInfo.Version))
+	}
+	result.WriteString(fmt.Sprintf("**Tables and Views**: %d  \n", objects))
+	result.WriteString(fmt.Sprintf("**Columns**: %d, of which %d have a description\n\n", columns, described))
+
+	if objects > 0 {
+		result.WriteString("## Contents\n\n")
+		result.WriteString(fmt.Sprintf("| %s | Object | Type | Description |\n", groupLabel))
+		result.WriteString("|---|--------|------|-------------|\n")
+		for _, group := range groups {
+			for _, table := range group.tables {
+				result.WriteString(fmt.Sprintf("| %s | [%s](#%s) | %s | %s |\n", markdownCell(group.label),
+					markdownCell(table.Name), markdownAnchor(f.name(table, withDB)), dictionaryObjectTypes[table.Type],
+					markdownCell(firstLine(table.Comment))))
+			}
+		}
+		result.WriteString("\n")
+	}
+
+	for _, group := range groups {
+		result.WriteString(fmt.Sprintf("## %s %s\n\n", groupLabel, group.label))
+		if group.comment != "" {
+			result.WriteString(group.comment + "\n\n")
+		}
+		for _, table := range group.tables {
+			result.WriteString(fmt.Sprintf("### %s\n\n", f.name(table, withDB)))
+			result.WriteString(fmt.Sprintf("**Type**: %s\n\n", dictionaryObjectTypes[table.Type]))
+			if table.Comment != "" {
+				result.WriteString(table.Comment + "\n\n")
+			} else {
+				result.WriteString("_No description._\n\n")
+			}
+			f.formatColumns(&result, table)
+		}
+	}
+
+	f.formatTypes(&result, info.Types)
+	f.formatRoutines(&result, info.Routines)
+
+	return strings.TrimSuffix(result.String(), "\n"), nil
+}
+
+func (f *DataDictionaryFormatter) GetFileExtension() string {
+	return ".md"
+}
+
+// groups returns the tables and views to document, grouped by database and schema.
+// Sequences and partitions, whose columns are those of their parent, are left out.
+func (f *DataDictionaryFormatter) groups(info *DatabaseInfo, withDB bool) []dictionaryGroup {
+	schemaComments := make(map[string]string)
+	for _, schema := range info.Schemas {
+		schemaComments[schema.Database+"."+schema.Name] = schema.Comment
+	}
+
+	byKey := make(map[string]*dictionaryGroup)
+	var keys []string
+	for _, table := range info.Tables {
+		if dictionaryObjectTypes[table.Type] == "" || table.PartitionOf != "" {
+			continue
+		}
+		key := table.Database + "." + table.Schema
+		group, ok := byKey[key]
+		if !ok {
+			label := table.Schema
+			if withDB {
+				label = table.Database + "." + table.Schema
+			}
+			// Schemas are labelled with their database only when all databases are collected
+			comment, found := schemaComments[key]
+			if !found {
+				comment = schemaComments["."+table.Schema]
+			}
+			group = &dictionaryGroup{label: label, comment: comment}
+			byKey[key] = group
+			keys = append(keys, key)
+		}
+		group.tables = append(group.tables, table)
+	}
+	sort.Strings(keys)
+
+	var groups []dictionaryGroup
+	for _, key := range keys {
+		group := byKey[key]
+		sort.Slice(group.tables, func(i, j int) bool {
+			return group.tables[i].Name < group.tables[j].Name
+		})
+		groups = append(groups, *group)
+	}
+	return groups
+}
+
+// name returns the heading of a table or view
+func (f *DataDictionaryFormatter) name(table TableInfo, withDB bool) string {
+	if withDB {
+		return qualifiedName(table.Database, table.Schema, table.Name)
+	}
+	return table.Schema + "." + table.Name
+}
+
+// formatColumns writes the column table of a table or view
+func (f *DataDictionaryFormatter) formatColumns(result *strings.Builder, table TableInfo) {
+	columns := f.columns(table)
+	if len(columns) == 0 {
+		return
+	}
+	keys := f.keys(table)
+
+	result.WriteString("| Column | Type | Nullable | Default | Key | Description |\n")
+	result.WriteString("|--------|------|----------|---------|-----|-------------|\n")
+	for _, column := range columns {
+		nullable := "No"
+		if column.Nullable {
+			nullable = "Yes"
+		}
+		defaultValue := column.Default
+		switch {
+		case column.AutoIncrement:
+			defaultValue = "auto-increment"
+		case column.Identity != "":
+			defaultValue = "identity"
+		case column.Generated != "":
+			defaultValue = "computed: " + column.Generated
+		}
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", markdownCell(column.Name),
+			markdownCell(column.Type), nullable, markdownCell(defaultValue),
+			markdownCell(strings.Join(keys[column.Name], ", ")), markdownCell(column.Comment)))
+	}
+	result.WriteString("\n")
+}
+
+// columns returns the columns of a table. Snapshots taken before the columns were
+// collected from the catalogs have only the DDL, so the columns are parsed from it.
+func (f *DataDictionaryFormatter) columns(table TableInfo) []ColumnInfo {
+	if len(table.Columns) > 0 || table.Type != "BASE TABLE" {
+		return table.Columns
+	}
+	parsed := parseTableDDL(table.DDL)
+	if parsed == nil {
+		return nil
+	}
+	var columns []ColumnInfo
+	for i, column := range parsed.Columns {
+		columns = append(columns, ColumnInfo{
+			Name:          column.Name,
+			Position:      i + 1,
+			Type:          column.Type,
+			Nullable:      column.Nullable,
+			Default:       column.Default,
+			AutoIncrement: column.AutoIncrement,
+			Identity:      column.Identity,
+			Generated:     column.Generated,
+			Comment:       column.Comment,
+		})
+	}
+	return columns
+}
+
+// keys labels the primary key, unique and foreign key columns of a table,
+// e.g. "PK" or "FK → shop.customers.id"
+func (f *DataDictionaryFormatter) keys(table TableInfo) map[string][]string {
+	indexes, foreignKeys := table.Indexes, table.ForeignKeys
+	if len(table.Columns) == 0 {
+		// See columns
+		if parsed := parseTableDDL(table.DDL); parsed != nil {
+			for _, index := range parsed.Indexes {
+				indexes = append(indexes, IndexInfo{Name: index.Name, Columns: index.Columns, Primary: index.Primary, Unique: index.Unique})
+			}
+			for _, fk := range parsed.ForeignKeys {
+				refSchema, refTable := table.Schema, fk.RefTable
+				if i := strings.LastIndex(fk.RefTable, "."); i >= 0 {
+					refSchema, refTable = fk.RefTable[:i], fk.RefTable[i+1:]
+				}
+				foreignKeys = append(foreignKeys, ForeignKeyInfo{Name: fk.Name, Columns: fk.Columns,
+					RefSchema: refSchema, RefTable: refTable, RefColumns: fk.RefColumns})
+			}
+		}
+	}
+
+	keys := make(map[string][]string)
+	for _, index := range indexes {
+		switch {
+		case index.Primary:
+			for _, column := range index.Columns {
+				keys[column] = append(keys[column], "PK")
+			}
+		case index.Unique && len(index.Columns) == 1 && index.Predicate == "":
+			keys[index.Columns[0]] = appendUnique(keys[index.Columns[0]], "Unique")
+		}
+	}
+	for _, fk := range foreignKeys {
+		for i, column := range fk.Columns {
+			ref := fk.RefSchema + "." + fk.RefTable
+			if i < len(fk.RefColumns) {
+				ref += "." + fk.RefColumns[i]
+			}
+			keys[column] = append(keys[column], "FK → "+ref)
+		}
+	}
+	return keys
+}
+
+// formatTypes writes the enum, composite, domain and range types (PostgreSQL)
+func (f *DataDictionaryFormatter) formatTypes(result *strings.Builder, types []TypeInfo) {
+	var documented []TypeInfo
+	for _, typeInfo := range types {
+		if typeInfo.Kind != "COLLATION" {
+			documented = append(documented, typeInfo)
+		}
+	}
+	if len(documented) == 0 {
+		return
+	}
+
+	result.WriteString("## Types\n\n")
+	result.WriteString("| Type | Kind | Values | Description |\n")
+	result.WriteString("|------|------|--------|-------------|\n")
+	for _, typeInfo := range documented {
+		var values string
+		switch typeInfo.Kind {
+		case "ENUM":
+			values = strings.Join(typeInfo.Labels, ", ")
+		case "COMPOSITE":
+			values = strings.Join(typeInfo.Attributes, ", ")
+		default:
+			values = typeInfo.BaseType
+		}
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			markdownCell(qualifiedName(typeInfo.Database, typeInfo.Schema, typeInfo.Name)),
+			strings.ToLower(typeInfo.Kind), markdownCell(values), markdownCell(typeInfo.Comment)))
+	}
+	result.WriteString("\n")
+}
+
+// formatRoutines writes the stored functions and procedures with their signatures
+func (f *DataDictionaryFormatter) formatRoutines(result *strings.Builder, routines []RoutineInfo) {
+	if len(routines) == 0 {
+		return
+	}
+
+	result.WriteString("## Routines\n\n")
+	result.WriteString("| Routine | Type | Arguments | Returns | Description |\n")
+	result.WriteString("|---------|------|-----------|---------|-------------|\n")
+	for _, routine := range routines {
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			markdownCell(qualifiedName(routine.Database, routine.Schema, routine.Name)),
+			strings.ToLower(routine.Type), markdownCell(routine.Parameters), markdownCell(routine.Returns),
+			markdownCell(routine.Comment)))
+	}
+	result.WriteString("\n")
+}
+
+// markdownAnchor returns the anchor GitHub generates for a heading
+func markdownAnchor(heading string) string {
+	var anchor strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			anchor.WriteRune('-')
+		case r == '-' || r == '_' || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r > 127:
+			anchor.WriteRune(r)
+		}
+	}
+	return anchor.String()
+}
+
+// firstLine returns the first line of a comment, for summaries
+func firstLine(s string) string {
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,494 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The DDL parser extracts columns, keys and foreign keys from the CREATE TABLE
+// statements collected in TableInfo.DDL: the output of SHOW CREATE TABLE on MySQL
+// and the statement assembled by PostgreSQLCollector.buildTableDDL on PostgreSQL.
+// It only understands those two layouts, not arbitrary SQL.
+
+// ddlTable is the parsed structure of a CREATE TABLE statement
+type ddlTable struct {
+	Columns     []ddlColumn
+	Indexes     []ddlIndex // includes the primary key
+	ForeignKeys []ddlForeignKey
+	Checks      []ddlCheck
+	Engine      string // MySQL
+	Charset     string // MySQL table default
+	Collation   string // MySQL table default
+}
+
+type ddlColumn struct {
+	Name          string
+	Type          string // lower case except quoted values, with length and modifiers, e.g. "varchar(50)", "int unsigned"
+	Nullable      bool
+	Default       string // "" when there is no default
+	Charset       string // MySQL column character set, if not the table default
+	Collation     string // MySQL column collation, if not the table default
+	AutoIncrement bool   // MySQL AUTO_INCREMENT
+	Identity      string // PostgreSQL GENERATED ... AS IDENTITY
+	Generated     string // generated column expression
+	OnUpdate      string // MySQL ON UPDATE, e.g. "CURRENT_TIMESTAMP(3)"
+	Comment       string
+}
+
+type ddlIndex struct {
+	Name    string
+	Columns []string // column names, or the expression of a functional key part
+	Primary bool
+	Unique  bool
+	Method  string // BTREE, HASH, FULLTEXT, SPATIAL, GIN, GIST, ...
+	Partial bool   // PostgreSQL index with a WHERE clause
+}
+
+type ddlForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string // as written in the DDL, schema-qualified on PostgreSQL
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+type ddlCheck struct {
+	Name       string
+	Expression string
+}
+
+// findColumn returns the column with the given name, or nil
+func (t *ddlTable) findColumn(name string) *ddlColumn {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// primaryKey returns the primary key, or nil
+func (t *ddlTable) primaryKey() *ddlIndex {
+	for i := range t.Indexes {
+		if t.Indexes[i].Primary {
+			return &t.Indexes[i]
+		}
+	}
+	return nil
+}
+
+var (
+	ddlCreateTableRe  = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:FOREIGN\s+|UNLOGGED\s+)?TABLE\s`)
+	ddlPartitionOfRe  = regexp.MustCompile(`(?i)\sPARTITION\s+OF\s`)
+	ddlCreateIndexRe  = regexp.MustCompile(`(?im)^\s*CREATE\s+(UNIQUE\s+)?INDEX\s+(\S+)\s+ON\s+(?:ONLY\s+)?(\S+)\s+USING\s+(\w+)\s*(\(.*)$`)
+	ddlTableOptionRe  = regexp.MustCompile(`(?i)\b(ENGINE|DEFAULT CHARSET|CHARSET|COLLATE)\s*=\s*(\w+)`)
+	ddlDefaultRe      = regexp.MustCompile(`(?i)\sDEFAULT\s+`)
+	ddlCharsetRe      = regexp.MustCompile(`(?i)\bCHARACTER SET (\w+)`)
+	ddlCollateRe      = regexp.MustCompile(`(?i)\bCOLLATE (\w+)`)
+	ddlCommentRe      = regexp.MustCompile(`(?i)\sCOMMENT\s+'((?:[^']|'')*)'`)
+	ddlOnUpdateRe     = regexp.MustCompile(`(?i)\sON UPDATE (CURRENT_TIMESTAMP(?:\(\d*\))?)`)
+	ddlIdentityRe     = regexp.MustCompile(`(?i)\bGENERATED\s+(ALWAYS|BY DEFAULT)\s+AS\s+IDENTITY`)
+	ddlGeneratedRe    = regexp.MustCompile(`(?i)\bGENERATED\s+ALWAYS\s+AS\s+\(`)
+	ddlMySQLKeyRe     = regexp.MustCompile("(?i)^(PRIMARY|UNIQUE|FULLTEXT|SPATIAL)?\\s*(?:KEY|INDEX)\\s*(`(?:[^`]|``)*`)?\\s*(\\(.*)$")
+	ddlIndexUsingRe   = regexp.MustCompile(`(?i)\bUSING\s+(BTREE|HASH)\b`)
+	ddlOnActionRe     = regexp.MustCompile(`(?i)\bON\s+(DELETE|UPDATE)\s+(SET NULL|SET DEFAULT|NO ACTION|CASCADE|RESTRICT)`)
+	ddlKeyPartOrderRe = regexp.MustCompile(`(?i)\s+(ASC|DESC)(\s+NULLS\s+(FIRST|LAST))?$`)
+	ddlKeyPrefixRe    = regexp.MustCompile("^(`(?:[^`]|``)*`)\\(\\d+\\)$")
+	ddlPGKeyPartRe    = regexp.MustCompile(`^("(?:[^"]|"")*"|[\w$]+)(\s+[\w.]+)?$`)
+)
+
+// parseTableDDL parses a CREATE TABLE statement. It returns nil when ddl does
+// not start with CREATE TABLE (views, sequences, ...) or creates a PostgreSQL
+// partition, whose parenthesis holds the partition bound instead of columns.
+func parseTableDDL(ddl string) *ddlTable {
+	if !ddlCreateTableRe.MatchString(ddl) {
+		return nil
+	}
+	open := strings.Index(ddl, "(")
+	if open < 0 || ddlPartitionOfRe.MatchString(ddl[:open]) {
+		return nil
+	}
+	closing := matchingParen(ddl, open)
+	if closing < 0 {
+		return nil
+	}
+
+	table := &ddlTable{}
+	for _, def := range splitTopLevel(ddl[open+1:closing], ',') {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+		table.parseDefinition(def)
+	}
+
+	// MySQL table options follow the closing parenthesis
+	rest := ddl[closing+1:]
+	options := rest
+	if end := strings.Index(options, ";"); end >= 0 {
+		options = options[:end]
+	}
+	for _, m := range ddlTableOptionRe.FindAllStringSubmatch(options, -1) {
+		switch strings.ToUpper(m[1]) {
+		case "ENGINE":
+			table.Engine = m[2]
+		case "DEFAULT CHARSET", "CHARSET":
+			table.Charset = m[2]
+		case "COLLATE":
+			table.Collation = m[2]
+		}
+	}
+
+	// PostgreSQL indexes that do not back a constraint follow as CREATE INDEX statements
+	for _, m := range ddlCreateIndexRe.FindAllStringSubmatch(rest, -1) {
+		keyParts := m[5]
+		end := matchingParen(keyParts, 0)
+		if end < 0 {
+			continue
+		}
+		table.Indexes = append(table.Indexes, ddlIndex{
+			Name:    unquoteIdent(m[2]),
+			Columns: parseKeyParts(keyParts[1:end]),
+			Unique:  m[1] != "",
+			Method:  strings.ToUpper(m[4]),
+			Partial: strings.Contains(strings.ToUpper(keyParts[end:]), " WHERE "),
+		})
+	}
+	return table
+}
+
+// parseDefinition parses one column or constraint definition of the CREATE TABLE body
+func (t *ddlTable) parseDefinition(def string) {
+	upper := strings.ToUpper(def)
+
+	var name string
+	if strings.HasPrefix(upper, "CONSTRAINT ") {
+		// CONSTRAINT name <constraint>
+		rest := strings.TrimSpace(def[len("CONSTRAINT "):])
+		name, rest = splitIdent(rest)
+		def = strings.TrimSpace(rest)
+		upper = strings.ToUpper(def)
+	}
+
+	switch {
+	case strings.HasPrefix(upper, "PRIMARY KEY"):
+		t.Indexes = append(t.Indexes, ddlIndex{
+			Name:    firstNonEmpty(name, "PRIMARY"),
+			Columns: parseKeyParts(parenContent(def)),
+			Primary: true,
+			Unique:  true,
+			Method:  "BTREE",
+		})
+	case strings.HasPrefix(upper, "FOREIGN KEY"):
+		t.ForeignKeys = append(t.ForeignKeys, parseForeignKey(name, def))
+	case strings.HasPrefix(upper, "CHECK"):
+		t.Checks = append(t.Checks, ddlCheck{Name: name, Expression: strings.TrimSpace(def[len("CHECK"):])})
+	case strings.HasPrefix(upper, "UNIQUE") && name != "":
+		// PostgreSQL: CONSTRAINT name UNIQUE (a, b)
+		t.Indexes = append(t.Indexes, ddlIndex{Name: name, Columns: parseKeyParts(parenContent(def)), Unique: true, Method: "BTREE"})
+	case strings.HasPrefix(upper, "EXCLUDE"):
+		// PostgreSQL exclusion constraints are not modelled
+	case name == "" && ddlMySQLKeyRe.MatchString(def) && !strings.HasPrefix(def, "`"):
+		m := ddlMySQLKeyRe.FindStringSubmatch(def)
+		index := ddlIndex{Name: unquoteIdent(m[2]), Method: "BTREE"}
+		switch strings.ToUpper(m[1]) {
+		case "PRIMARY":
+			index.Name, index.Primary, index.Unique = "PRIMARY", true, true
+		case "UNIQUE":
+			index.Unique = true
+		case "FULLTEXT", "SPATIAL":
+			index.Method = strings.ToUpper(m[1])
+		}
+		if using := ddlIndexUsingRe.FindStringSubmatch(m[3]); using != nil {
+			index.Method = strings.ToUpper(using[1])
+		}
+		index.Columns = parseKeyParts(parenContent(m[3]))
+		t.Indexes = append(t.Indexes, index)
+	default:
+		t.Columns = append(t.Columns, parseColumn(def))
+	}
+}
+
+// parseColumn parses a column definition such as
+// "`name` varchar(50) CHARACTER SET latin1 DEFAULT NULL" or "id integer NOT NULL DEFAULT nextval(...)"
+func parseColumn(def string) ddlColumn {
+	name, rest := splitIdent(def)
+	column := ddlColumn{Name: name, Nullable: true}
+	rest = strings.TrimSpace(rest)
+	upper := strings.ToUpper(rest)
+
+	// The type ends at the first attribute keyword
+	typeEnd := len(rest)
+	for _, keyword := range []string{" NOT NULL", " NULL", " DEFAULT ", " CHARACTER SET ", " COLLATE ", " AUTO_INCREMENT",
+		" GENERATED ", " COMMENT ", " ON UPDATE ", " PRIMARY KEY", " UNIQUE", " REFERENCES ", " CHECK ", " INVISIBLE", " VISIBLE",
+		" STORED", " VIRTUAL", " SRID "} {
+		if i := indexTopLevel(upper, keyword); i >= 0 && i < typeEnd {
+			typeEnd = i
+		}
+	}
+	column.Type = lowerOutsideQuotes(strings.TrimSpace(rest[:typeEnd]))
+	attrs := rest[typeEnd:]
+	upperAttrs := strings.ToUpper(attrs)
+
+	if indexTopLevel(upperAttrs, " NOT NULL") >= 0 {
+		column.Nullable = false
+	}
+	if indexTopLevel(upperAttrs, " AUTO_INCREMENT") >= 0 {
+		column.AutoIncrement = true
+	}
+	if m := ddlCharsetRe.FindStringSubmatch(attrs); m != nil {
+		column.Charset = m[1]
+	}
+	if m := ddlCollateRe.FindStringSubmatch(attrs); m != nil {
+		column.Collation = m[1]
+	}
+	if m := ddlIdentityRe.FindStringSubmatch(attrs); m != nil {
+		column.Identity = strings.ToUpper(m[1])
+	}
+	if loc := ddlGeneratedRe.FindStringIndex(attrs); loc != nil {
+		if end := matchingParen(attrs, loc[1]-1); end > 0 {
+			column.Generated = attrs[loc[1]:end]
+		}
+	}
+	if m := ddlOnUpdateRe.FindStringSubmatch(attrs); m != nil && indexTopLevel(upperAttrs, " ON UPDATE ") >= 0 {
+		column.OnUpdate = strings.ToUpper(m[1])
+	}
+	if m := ddlCommentRe.FindStringSubmatch(attrs); m != nil {
+		column.Comment = strings.ReplaceAll(m[1], "''", "'")
+	}
+	if loc := ddlDefaultRe.FindStringIndex(attrs); loc != nil && indexTopLevel(upperAttrs, " DEFAULT ") >= 0 {
+		value := attrs[loc[1]:]
+		// The default runs until the next attribute keyword
+		upperValue := strings.ToUpper(value)
+		end := len(value)
+		for _, keyword := range []string{" NOT NULL", " ON UPDATE ", " COMMENT ", " AUTO_INCREMENT", " COLLATE ", " INVISIBLE", " VISIBLE"} {
+			if i := indexTopLevel(upperValue, keyword); i >= 0 && i < end {
+				end = i
+			}
+		}
+		column.Default = strings.TrimSpace(value[:end])
+	}
+	return column
+}
+
+// parseForeignKey parses "FOREIGN KEY (a) REFERENCES t (b) ON DELETE CASCADE"
+func parseForeignKey(name, def string) ddlForeignKey {
+	fk := ddlForeignKey{Name: name}
+	open := strings.Index(def, "(")
+	if open < 0 {
+		return fk
+	}
+	closing := matchingParen(def, open)
+	if closing < 0 {
+		return fk
+	}
+	fk.Columns = parseKeyParts(def[open+1 : closing])
+
+	rest := strings.TrimSpace(def[closing+1:])
+	if strings.HasPrefix(strings.ToUpper(rest), "REFERENCES") {
+		rest = strings.TrimSpace(rest[len("REFERENCES"):])
+		refOpen := strings.Index(rest, "(")
+		if refOpen > 0 {
+			fk.RefTable = unquoteQualified(strings.TrimSpace(rest[:refOpen]))
+			if refClose := matchingParen(rest, refOpen); refClose > 0 {
+				fk.RefColumns = parseKeyParts(rest[refOpen+1 : refClose])
+			}
+		}
+	}
+	for _, m := range ddlOnActionRe.FindAllStringSubmatch(def, -1) {
+		if strings.EqualFold(m[1], "DELETE") {
+			fk.OnDelete = strings.ToUpper(m[2])
+		} else {
+			fk.OnUpdate = strings.ToUpper(m[2])
+		}
+	}
+	return fk
+}
+
+// parseKeyParts splits a key column list into column names. Prefix lengths,
+// sort orders and operator classes are dropped; expressions are kept as written.
+func parseKeyParts(list string) []string {
+	var parts []string
+	for _, part := range splitTopLevel(list, ',') {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		part = ddlKeyPartOrderRe.ReplaceAllString(part, "")
+		if m := ddlKeyPrefixRe.FindStringSubmatch(part); m != nil {
+			part = m[1]
+		}
+		if m := ddlPGKeyPartRe.FindStringSubmatch(part); m != nil {
+			part = m[1]
+		}
+		parts = append(parts, unquoteIdent(part))
+	}
+	return parts
+}
+
+// parenContent returns the content of the first parenthesised group in s
+func parenContent(s string) string {
+	open := strings.Index(s, "(")
+	if open < 0 {
+		return ""
+	}
+	closing := matchingParen(s, open)
+	if closing < 0 {
+		return ""
+	}
+	return s[open+1 : closing]
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open, or -1
+func matchingParen(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s at sep characters outside parentheses and quotes
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// indexTopLevel returns the index of substr in s outside parentheses and quotes, or -1
+func indexTopLevel(s, substr string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			continue
+		case c == '(':
+			depth++
+			continue
+		case c == ')':
+			depth--
+			continue
+		}
+		if depth == 0 && strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitIdent splits a leading (possibly quoted) identifier from the rest of s
+func splitIdent(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ""
+	}
+	if q := s[0]; q == '`' || q == '"' {
+		for i := 1; i < len(s); i++ {
+			if s[i] != q {
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == q {
+				i++ // doubled quote
+				continue
+			}
+			return unquoteIdent(s[:i+1]), s[i+1:]
+		}
+		return unquoteIdent(s), ""
+	}
+	if i := strings.IndexAny(s, " \t\n("); i >= 0 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// unquoteIdent removes backticks or double quotes around an identifier
+func unquoteIdent(s string) string {
+	if len(s) >= 2 {
+		if q := s[0]; (q == '`' || q == '"') && s[len(s)-1] == q {
+			inner := s[1 : len(s)-1]
+			return strings.ReplaceAll(inner, string(q)+string(q), string(q))
+		}
+	}
+	return s
+}
+
+// unquoteQualified unquotes each part of a dotted name such as `db`.`table`
+func unquoteQualified(s string) string {
+	parts := splitTopLevel(s, '.')
+	for i, part := range parts {
+		parts[i] = unquoteIdent(strings.TrimSpace(part))
+	}
+	return strings.Join(parts, ".")
+}
+
+// lowerOutsideQuotes lower-cases s except for quoted strings such as enum values
+func lowerOutsideQuotes(s string) string {
+	b := []byte(s)
+	var quote byte
+	for i, c := range b {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case 'A' <= c && c <= 'Z':
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
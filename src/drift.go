@@ -0,0 +1,351 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Drift detection compares the collected table/view DDL and routine definitions
+// against a directory of expected .sql files, one file per object.
+// A file is matched to an object by its name: "<schema>.<name>.sql", "<schema>/<name>.sql"
+// or just "<name>.sql", and by the kind of object its CREATE statement creates, so that
+// a table and a routine, or a procedure and a function, of the same name are told apart.
+
+// DriftItem describes one object that is missing, extra or different
+type DriftItem struct {
+	Object   string `json:"object"`
+	Kind     string `json:"kind,omitempty"` // table, view, sequence, function, procedure
+	File     string `json:"file,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// DriftResult holds the result of a drift check
+type DriftResult struct {
+	SchemaVersion string      `json:"schema_version"`
+	Directory     string      `json:"directory"`
+	Missing       []DriftItem `json:"missing"` // expected but not in the database
+	Extra         []DriftItem `json:"extra"`   // in the database but no expected file
+	Changed       []DriftItem `json:"changed"` // definition differs after normalisation
+}
+
+// HasDrift reports whether any drift was found
+func (r *DriftResult) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Extra) > 0 || len(r.Changed) > 0
+}
+
+// driftObject is a live object that can be compared against an expected file
+type driftObject struct {
+	schema     string
+	name       string
+	kind       string
+	definition string
+}
+
+func (o driftObject) fullName() string {
+	return o.schema + "." + o.name
+}
+
+// key identifies an object: a table and a routine can have the same name
+func (o driftObject) key() string {
+	return o.kind + " " + o.fullName()
+}
+
+// createKindRe matches the kind of object created by a CREATE statement, after any
+// OR REPLACE, ALGORITHM, SQL SECURITY, TEMPORARY or UNLOGGED clause
+var createKindRe = regexp.MustCompile(`(?i)^CREATE\s+(?:[\w=]+\s+)*?(?:MATERIALIZED\s+)?(TABLE|VIEW|SEQUENCE|FUNCTION|PROCEDURE)\b`)
+
+// expectedKind returns the kind of object an expected file creates, or "" when it is
+// not a CREATE statement, such as a MySQL routine body
+func expectedKind(content string) string {
+	m := createKindRe.FindStringSubmatch(normalizeDDL(content))
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(m[1])
+}
+
+// CheckDrift compares the collected objects against the expected .sql files in dir
+func CheckDrift(info *DatabaseInfo, dir string) (*DriftResult, error) {
+	expected, err := readExpectedDDL(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []driftObject
+	for _, table := range info.Tables {
+		kind := "table"
+		switch table.Type {
+		case "VIEW", "MATERIALIZED VIEW":
+			kind = "view"
+		case "SEQUENCE":
+			kind = "sequence"
+		}
+		objects = append(objects, driftObject{table.Schema, table.Name, kind, table.DDL})
+	}
+	for _, routine := range info.Routines {
+		definition := routine.Definition
+		if routine.DDL != "" {
+			definition = routine.DDL
+		}
+		objects = append(objects, driftObject{routine.Schema, routine.Name, strings.ToLower(routine.Type), definition})
+	}
+
+	// Index live objects by "schema.name" and by bare name
+	byFullName := make(map[string][]driftObject)
+	byName := make(map[string][]driftObject)
+	for _, obj := range objects {
+		byFullName[obj.fullName()] = append(byFullName[obj.fullName()], obj)
+		byName[obj.name] = append(byName[obj.name], obj)
+	}
+
+	result := &DriftResult{SchemaVersion: JSONSchemaVersion, Directory: dir}
+	matched := make(map[string]bool)
+
+	var keys []string
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		file := expected[key]
+		candidates := byFullName[key]
+		if len(candidates) == 0 {
+			candidates = byName[key]
+		}
+		kind := expectedKind(file.content)
+		if kind != "" {
+			var sameKind []driftObject
+			for _, obj := range candidates {
+				if obj.kind == kind {
+					sameKind = append(sameKind, obj)
+				}
+			}
+			candidates = sameKind
+		}
+		if len(candidates) == 0 {
+			result.Missing = append(result.Missing, DriftItem{Object: key, Kind: kind, File: file.path})
+			continue
+		}
+		if len(candidates) > 1 {
+			var names []string
+			for _, obj := range candidates {
+				names = append(names, obj.key())
+			}
+			return nil, fmt.Errorf("%s matches several objects (%s); name the file <schema>.<name>.sql and start it with its CREATE statement",
+				file.path, strings.Join(names, ", "))
+		}
+		obj := candidates[0]
+		if matched[obj.key()] {
+			return nil, fmt.Errorf("several files match %s %s", obj.kind, obj.fullName())
+		}
+		matched[obj.key()] = true
+
+		if !definitionsMatch(file.content, obj.definition) {
+			result.Changed = append(result.Changed, DriftItem{
+				Object:   obj.fullName(),
+				Kind:     obj.kind,
+				File:     file.path,
+				Expected: normalizeDDLLines(file.content),
+				Actual:   normalizeDDLLines(obj.definition),
+			})
+		}
+	}
+
+	for _, obj := range objects {
+		if !matched[obj.key()] {
+			result.Extra = append(result.Extra, DriftItem{Object: obj.fullName(), Kind: obj.kind})
+		}
+	}
+
+	return result, nil
+}
+
+type expectedFile struct {
+	path    string
+	content string
+}
+
+// readExpectedDDL reads all .sql files below dir, keyed by object name
+func readExpectedDDL(dir string) (map[string]expectedFile, error) {
+	files := make(map[string]expectedFile)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".sql") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		key := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if rel, err := filepath.Rel(dir, filepath.Dir(path)); err == nil && rel != "." && !strings.Contains(key, ".") {
+			// <schema>/<name>.sql
+			key = filepath.Base(rel) + "." + key
+		}
+		if prev, ok := files[key]; ok {
+			return fmt.Errorf("both %s and %s define %s", prev.path, path, key)
+		}
+		files[key] = expectedFile{path: path, content: string(content)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+var (
+	definerRe         = regexp.MustCompile("(?i)\\s+DEFINER\\s*=\\s*(`[^`]*`|'[^']*'|[^\\s@]+)@(`[^`]*`|'[^']*'|[^\\s]+)")
+	delimiterLineRe   = regexp.MustCompile(`(?im)^\s*DELIMITER\s+\S+\s*$`)
+	lineCommentRe     = regexp.MustCompile(`(?m)^\s*--.*$`)
+	trailingDelimRe   = regexp.MustCompile(`\s*(;|\$\$|//)\s*$`)
+	whitespaceRe      = regexp.MustCompile(`\s+`)
+	createStatementRe = regexp.MustCompile(`(?i)^\s*CREATE\s`)
+	blankLinesRe      = regexp.MustCompile(`\n{2,}`)
+	trailingSpacesRe  = regexp.MustCompile(`(?m)[ \t]+$`)
+)
+
+// normalizeDDLLines removes definer clauses, AUTO_INCREMENT counters, comments,
+// delimiters and trailing whitespace while keeping the line structure
+func normalizeDDLLines(ddl string) string {
+	ddl = strings.ReplaceAll(ddl, "\r\n", "\n")
+	ddl = delimiterLineRe.ReplaceAllString(ddl, "")
+	ddl = lineCommentRe.ReplaceAllString(ddl, "")
+	ddl = definerRe.ReplaceAllString(ddl, "")
+	ddl = normalizeDDLForDiff(ddl)
+	ddl = trailingSpacesRe.ReplaceAllString(ddl, "")
+	ddl = blankLinesRe.ReplaceAllString(ddl, "\n")
+	ddl = strings.TrimSpace(ddl)
+	return trailingDelimRe.ReplaceAllString(ddl, "")
+}
+
+// normalizeDDL additionally collapses all whitespace into single spaces
+func normalizeDDL(ddl string) string {
+	return whitespaceRe.ReplaceAllString(normalizeDDLLines(ddl), " ")
+}
+
+// definitionsMatch compares an expected definition with a live one after normalisation.
+// MySQL routines are compared with their SHOW CREATE statement, so that changed parameters,
+// return types and characteristics are drift. Snapshots taken before it was collected only
+// have the routine body, so when the expected file is a full CREATE statement and the live
+// definition is not, only the body is compared.
+func definitionsMatch(expected, actual string) bool {
+	e := normalizeDDL(expected)
+	a := normalizeDDL(actual)
+	if e == a {
+		return true
+	}
+	if a != "" && createStatementRe.MatchString(e) && !createStatementRe.MatchString(a) {
+		return strings.HasSuffix(e, " "+a)
+	}
+	return false
+}
+
+// FormatDrift renders a DriftResult in the given output format
+func FormatDrift(result *DriftResult, format OutputFormat) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return formatDriftMarkdown(result), nil
+	case FormatPlaintext:
+		return formatDriftPlaintext(result), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported drift format: %s", format)
+	}
+}
+
+func formatDriftMarkdown(result *DriftResult) string {
+	var out strings.Builder
+
+	out.WriteString("# Schema Drift\n\n")
+	out.WriteString(fmt.Sprintf("**Expected DDL**: %s\n\n", result.Directory))
+	if !result.HasDrift() {
+		out.WriteString("No drift found.\n")
+		return out.String()
+	}
+
+	if len(result.Missing) > 0 {
+		out.WriteString("## Missing Objects\n\n")
+		for _, item := range result.Missing {
+			out.WriteString(fmt.Sprintf("- %s (%s)\n", item.Object, item.File))
+		}
+		out.WriteString("\n")
+	}
+	if len(result.Extra) > 0 {
+		out.WriteString("## Extra Objects\n\n")
+		for _, item := range result.Extra {
+			out.WriteString(fmt.Sprintf("- %s (%s)\n", item.Object, item.Kind))
+		}
+		out.WriteString("\n")
+	}
+	if len(result.Changed) > 0 {
+		out.WriteString("## Changed Objects\n\n")
+		for _, item := range result.Changed {
+			out.WriteString(fmt.Sprintf("### %s (%s)\n\n", item.Object, item.Kind))
+			out.WriteString(fmt.Sprintf("- Expected: %s\n\n", item.File))
+			out.WriteString("```diff\n")
+			out.WriteString(strings.Join(diffLines(item.Expected, item.Actual), "\n"))
+			out.WriteString("\n```\n\n")
+		}
+	}
+	return out.String()
+}
+
+func formatDriftPlaintext(result *DriftResult) string {
+	var out strings.Builder
+
+	out.WriteString("Schema Drift\n")
+	out.WriteString("============\n\n")
+	out.WriteString(fmt.Sprintf("Expected DDL: %s\n\n", result.Directory))
+	if !result.HasDrift() {
+		out.WriteString("No drift found.\n")
+		return out.String()
+	}
+
+	if len(result.Missing) > 0 {
+		out.WriteString("Missing Objects\n")
+		out.WriteString("---------------\n")
+		for _, item := range result.Missing {
+			out.WriteString(fmt.Sprintf("  %s (%s)\n", item.Object, item.File))
+		}
+		out.WriteString("\n")
+	}
+	if len(result.Extra) > 0 {
+		out.WriteString("Extra Objects\n")
+		out.WriteString("-------------\n")
+		for _, item := range result.Extra {
+			out.WriteString(fmt.Sprintf("  %s (%s)\n", item.Object, item.Kind))
+		}
+		out.WriteString("\n")
+	}
+	if len(result.Changed) > 0 {
+		out.WriteString("Changed Objects\n")
+		out.WriteString("---------------\n")
+		for _, item := range result.Changed {
+			out.WriteString(fmt.Sprintf("  %s (%s, expected: %s)\n", item.Object, item.Kind, item.File))
+			for _, line := range diffLines(item.Expected, item.Actual) {
+				out.WriteString(fmt.Sprintf("    %s\n", line))
+			}
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
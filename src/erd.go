@@ -0,0 +1,353 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The entity-relationship diagram is built from the columns, keys and foreign
+// keys of the base tables (see baseTables), and rendered as Mermaid, Graphviz
+// DOT or PlantUML. The Markdown report embeds the Mermaid diagram.
+
+// erd is the diagram of the collected base tables
+type erd struct {
+	Entities  []erdEntity
+	Relations []erdRelation
+}
+
+type erdEntity struct {
+	ID      string // identifier usable in every diagram language
+	Label   string // table name, schema-qualified when several schemas are collected
+	Columns []erdColumn
+}
+
+type erdColumn struct {
+	Name     string
+	Type     string
+	Keys     []string // PK, FK, UK
+	Nullable bool
+}
+
+// erdRelation is a foreign key from Child to Parent
+type erdRelation struct {
+	Parent   string // entity IDs
+	Child    string
+	Label    string // constraint name, or the foreign key columns
+	Optional bool   // a foreign key column is nullable: the child may have no parent
+	Unique   bool   // the foreign key columns are unique: one child per parent at most
+}
+
+// buildERD builds the diagram of the base tables of info. Tables referenced by
+// a foreign key but not collected are added without columns.
+func buildERD(info *DatabaseInfo) *erd {
+	tables := baseTables(info)
+
+	schemas := make(map[string]bool)
+	for _, table := range tables {
+		schemas[table.info.Database+"."+table.info.Schema] = true
+	}
+	label := func(table lintTable) string {
+		if len(schemas) > 1 {
+			return table.name()
+		}
+		return table.info.Name
+	}
+
+	diagram := &erd{}
+	ids := make(map[string]string) // database.schema.name -> entity ID
+	for _, table := range tables {
+		ids[table.info.Database+"."+table.info.Schema+"."+table.info.Name] = erdID(table.name())
+	}
+
+	for _, table := range tables {
+		parsed := table.structure
+		entity := erdEntity{ID: erdID(table.name()), Label: label(table)}
+
+		keys := make(map[string][]string)
+		if pk := parsed.primaryKey(); pk != nil {
+			for _, column := range pk.Columns {
+				keys[column] = append(keys[column], "PK")
+			}
+		}
+		for _, fk := range parsed.ForeignKeys {
+			for _, column := range fk.Columns {
+				keys[column] = appendUnique(keys[column], "FK")
+			}
+		}
+		for _, index := range parsed.Indexes {
+			if index.Unique && !index.Primary && len(index.Columns) == 1 {
+				keys[index.Columns[0]] = appendUnique(keys[index.Columns[0]], "UK")
+			}
+		}
+		for _, column := range parsed.Columns {
+			entity.Columns = append(entity.Columns, erdColumn{
+				Name:     column.Name,
+				Type:     column.Type,
+				Keys:     keys[column.Name],
+				Nullable: column.Nullable,
+			})
+		}
+		diagram.Entities = append(diagram.Entities, entity)
+
+		for _, fk := range parsed.ForeignKeys {
+			schema, name := table.info.Schema, fk.RefTable
+			if i := strings.LastIndex(fk.RefTable, "."); i >= 0 {
+				schema, name = fk.RefTable[:i], fk.RefTable[i+1:]
+			}
+			key := table.info.Database + "." + schema + "." + name
+			parent, ok := ids[key]
+			if !ok {
+				refName := schema + "." + name
+				if table.withDB {
+					refName = qualifiedName(table.info.Database, schema, name)
+				}
+				parent = erdID(refName)
+				ids[key] = parent
+				refLabel := name
+				if len(schemas) > 1 || schema != table.info.Schema {
+					refLabel = refName
+				}
+				diagram.Entities = append(diagram.Entities, erdEntity{ID: parent, Label: refLabel})
+			}
+
+			relation := erdRelation{Parent: parent, Child: entity.ID, Label: firstNonEmpty(fk.Name, strings.Join(fk.Columns, ", "))}
+			for _, column := range fk.Columns {
+				if c := parsed.findColumn(column); c != nil && c.Nullable {
+					relation.Optional = true
+				}
+			}
+			for _, index := range parsed.Indexes {
+				if index.Unique && sameColumns(index.Columns, fk.Columns) {
+					relation.Unique = true
+				}
+			}
+			diagram.Relations = append(diagram.Relations, relation)
+		}
+	}
+	return diagram
+}
+
+// erdID turns a table name into an identifier: letters, digits and underscores
+func erdID(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// sameColumns reports whether a and b hold the same columns in any order
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// crowsFoot returns the crow's foot notation of a relation, shared by Mermaid and PlantUML
+func (r erdRelation) crowsFoot() string {
+	parent, child := "||", "o{"
+	if r.Optional {
+		parent = "|o"
+	}
+	if r.Unique {
+		child = "o|"
+	}
+	return parent + "--" + child
+}
+
+// mermaidType makes a column type fit Mermaid's attribute type syntax, which
+// has no spaces, commas or quotes: "int unsigned" becomes "int_unsigned" and
+// "decimal(10,2)" becomes "decimal"
+func mermaidType(columnType string) string {
+	name, args, _ := splitMySQLType(columnType)
+	rest := strings.TrimPrefix(columnType, name)
+	if strings.HasPrefix(rest, "(") {
+		if end := matchingParen(rest, 0); end > 0 {
+			rest = rest[end+1:]
+		}
+		if !strings.ContainsAny(args, ",'\"") {
+			name += "(" + args + ")"
+		}
+	}
+	return strings.Join(append([]string{name}, strings.Fields(rest)...), "_")
+}
+
+// writeMermaid writes a Mermaid erDiagram
+func (d *erd) writeMermaid(result *strings.Builder) {
+	result.WriteString("erDiagram\n")
+	for _, entity := range d.Entities {
+		name := entity.ID
+		if entity.Label != entity.ID {
+			name += fmt.Sprintf("[\"%s\"]", entity.Label)
+		}
+		if len(entity.Columns) == 0 {
+			result.WriteString(fmt.Sprintf("    %s {\n    }\n", name))
+			continue
+		}
+		result.WriteString(fmt.Sprintf("    %s {\n", name))
+		for _, column := range entity.Columns {
+			line := fmt.Sprintf("        %s %s", mermaidType(column.Type), erdID(column.Name))
+			if len(column.Keys) > 0 {
+				line += " " + strings.Join(column.Keys, ", ")
+			}
+			if column.Name != erdID(column.Name) {
+				line += fmt.Sprintf(" \"%s\"", strings.ReplaceAll(column.Name, `"`, "'"))
+			}
+			result.WriteString(line + "\n")
+		}
+		result.WriteString("    }\n")
+	}
+	for _, relation := range d.Relations {
+		result.WriteString(fmt.Sprintf("    %s %s %s : \"%s\"\n", relation.Parent, relation.crowsFoot(), relation.Child,
+			strings.ReplaceAll(relation.Label, `"`, "'")))
+	}
+}
+
+// MermaidFormatter formats the entity-relationship diagram as Mermaid
+type MermaidFormatter struct{}
+
+func (f *MermaidFormatter) Format(info *DatabaseInfo) (string, error) {
+	var result strings.Builder
+	buildERD(info).writeMermaid(&result)
+	return result.String(), nil
+}
+
+func (f *MermaidFormatter) GetFileExtension() string {
+	return ".mmd"
+}
+
+// DOTFormatter formats the entity-relationship diagram as Graphviz DOT
+type DOTFormatter struct{}
+
+func (f *DOTFormatter) Format(info *DatabaseInfo) (string, error) {
+	d := buildERD(info)
+	var result strings.Builder
+	result.WriteString("digraph erd {\n")
+	result.WriteString("    rankdir=LR;\n")
+	result.WriteString("    node [shape=plain, fontname=\"Helvetica\"];\n")
+	result.WriteString("    edge [fontname=\"Helvetica\", fontsize=10, arrowhead=crow, arrowtail=tee, dir=both];\n\n")
+	for _, entity := range d.Entities {
+		result.WriteString(fmt.Sprintf("    %s [label=<\n", entity.ID))
+		result.WriteString("        <table border=\"0\" cellborder=\"1\" cellspacing=\"0\" cellpadding=\"4\">\n")
+		result.WriteString(fmt.Sprintf("            <tr><td bgcolor=\"lightgrey\" colspan=\"2\"><b>%s</b></td></tr>\n", f.escapeHTML(entity.Label)))
+		for _, column := range entity.Columns {
+			name := f.escapeHTML(column.Name)
+			if len(column.Keys) > 0 {
+				name = fmt.Sprintf("%s <i>%s</i>", name, strings.Join(column.Keys, ", "))
+			}
+			if containsString(column.Keys, "PK") {
+				name = "<u>" + name + "</u>"
+			}
+			result.WriteString(fmt.Sprintf("            <tr><td align=\"left\" port=\"%s\">%s</td><td align=\"left\">%s</td></tr>\n",
+				erdID(column.Name), name, f.escapeHTML(column.Type)))
+		}
+		result.WriteString("        </table>\n")
+		result.WriteString("    >];\n")
+	}
+	if len(d.Relations) > 0 {
+		result.WriteString("\n")
+	}
+	for _, relation := range d.Relations {
+		attrs := fmt.Sprintf("label=\"%s\"", strings.ReplaceAll(relation.Label, `"`, `\"`))
+		if relation.Optional {
+			attrs += ", arrowtail=teeodot"
+		}
+		if relation.Unique {
+			attrs += ", arrowhead=teeodot"
+		}
+		result.WriteString(fmt.Sprintf("    %s -> %s [%s];\n", relation.Parent, relation.Child, attrs))
+	}
+	result.WriteString("}\n")
+	return result.String(), nil
+}
+
+func (f *DOTFormatter) escapeHTML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;").Replace(s)
+}
+
+func (f *DOTFormatter) GetFileExtension() string {
+	return ".dot"
+}
+
+// PlantUMLFormatter formats the entity-relationship diagram as PlantUML
+type PlantUMLFormatter struct{}
+
+func (f *PlantUMLFormatter) Format(info *DatabaseInfo) (string, error) {
+	d := buildERD(info)
+	var result strings.Builder
+	result.WriteString("@startuml\n")
+	result.WriteString("hide circle\n")
+	result.WriteString("skinparam linetype ortho\n\n")
+	for _, entity := range d.Entities {
+		result.WriteString(fmt.Sprintf("entity \"%s\" as %s {\n", entity.Label, entity.ID))
+		// Primary key columns come first, above a separator
+		var keyColumns, otherColumns []erdColumn
+		for _, column := range entity.Columns {
+			if containsString(column.Keys, "PK") {
+				keyColumns = append(keyColumns, column)
+			} else {
+				otherColumns = append(otherColumns, column)
+			}
+		}
+		writeColumn := func(column erdColumn) {
+			line := "  "
+			if !column.Nullable {
+				line += "* "
+			}
+			line += fmt.Sprintf("%s : %s", column.Name, column.Type)
+			for _, key := range column.Keys {
+				line += fmt.Sprintf(" <<%s>>", key)
+			}
+			result.WriteString(line + "\n")
+		}
+		for _, column := range keyColumns {
+			writeColumn(column)
+		}
+		if len(keyColumns) > 0 {
+			result.WriteString("  --\n")
+		}
+		for _, column := range otherColumns {
+			writeColumn(column)
+		}
+		result.WriteString("}\n\n")
+	}
+	for _, relation := range d.Relations {
+		result.WriteString(fmt.Sprintf("%s %s %s : %s\n", relation.Parent, relation.crowsFoot(), relation.Child, relation.Label))
+	}
+	result.WriteString("@enduml\n")
+	return result.String(), nil
+}
+
+func (f *PlantUMLFormatter) GetFileExtension() string {
+	return ".puml"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
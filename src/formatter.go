@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"sort"
@@ -15,8 +16,17 @@ const (
 	FormatMarkdown  OutputFormat = "markdown"
 	FormatXML       OutputFormat = "xml"
 	FormatPlaintext OutputFormat = "plaintext"
+	FormatJSON      OutputFormat = "json"
+	FormatMermaid   OutputFormat = "mermaid"  // ER diagram
+	FormatDOT       OutputFormat = "dot"      // ER diagram
+	FormatPlantUML  OutputFormat = "plantuml" // ER diagram
 )
 
+// JSONSchemaVersion is the version of the JSON output schema described in
+// schema/databasemix.schema.json. It is bumped when a field is renamed or removed;
+// adding new fields keeps the same version.
+const JSONSchemaVersion = "1.0"
+
 // Formatter interface for different output formats
 type Formatter interface {
 	Format(info *DatabaseInfo) (string, error)
@@ -32,11 +42,203 @@ func NewFormatter(format OutputFormat) (Formatter, error) {
 		return &XMLFormatter{}, nil
 	case FormatPlaintext:
 		return &PlaintextFormatter{}, nil
+	case FormatJSON:
+		return &JSONFormatter{}, nil
+	case FormatMermaid:
+		return &MermaidFormatter{}, nil
+	case FormatDOT:
+		return &DOTFormatter{}, nil
+	case FormatPlantUML:
+		return &PlantUMLFormatter{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
+// partitionsByParent maps each partitioned table ("schema.name") to the names of its partitions
+func partitionsByParent(tables []TableInfo) map[string][]string {
+	partitions := make(map[string][]string)
+	for _, table := range tables {
+		if table.PartitionOf != "" {
+			partitions[table.PartitionOf] = append(partitions[table.PartitionOf], table.Schema+"."+table.Name)
+		}
+	}
+	for _, children := range partitions {
+		sort.Strings(children)
+	}
+	return partitions
+}
+
+// qualifiedName returns schema.name, prefixed with the database when it is set
+func qualifiedName(database, schema, name string) string {
+	if database != "" {
+		return database + "." + schema + "." + name
+	}
+	return schema + "." + name
+}
+
+// databaseObjectName prefixes the name of a PostgreSQL object that belongs to a database
+// but not to a schema, such as a publication, with its database when one is set
+func databaseObjectName(database, name string) string {
+	if database != "" {
+		return database + "." + name
+	}
+	return name
+}
+
+// rowSecurityLabel describes the row-level security state of a table, or "" when it is off
+func rowSecurityLabel(table TableInfo) string {
+	switch {
+	case table.RowSecurity && table.ForceRowSecurity:
+		return "ENABLED, FORCED"
+	case table.RowSecurity:
+		return "ENABLED"
+	case table.ForceRowSecurity:
+		return "DISABLED, FORCED"
+	}
+	return ""
+}
+
+// policySummary describes a row-level security policy in one line
+func policySummary(policy PolicyInfo) string {
+	summary := fmt.Sprintf("%s (FOR %s", policy.Name, policy.Command)
+	if len(policy.Roles) > 0 {
+		summary += " TO " + strings.Join(policy.Roles, ", ")
+	}
+	if policy.Permissive == "RESTRICTIVE" {
+		summary += ", RESTRICTIVE"
+	}
+	return summary + ")"
+}
+
+// tableStatsGroups groups the tables carrying statistics by schema. The group label
+// includes the database when the tables span several databases.
+func tableStatsGroups(tables []TableInfo) ([]string, map[string][]TableInfo) {
+	databases := make(map[string]bool)
+	for _, table := range tables {
+		if table.Stats != nil {
+			databases[table.Database] = true
+		}
+	}
+
+	groups := make(map[string][]TableInfo)
+	for _, table := range tables {
+		if table.Stats == nil {
+			continue
+		}
+		label := table.Schema
+		if len(databases) > 1 && table.Database != table.Schema {
+			label = table.Database + "." + table.Schema
+		}
+		groups[label] = append(groups[label], table)
+	}
+
+	var labels []string
+	for label, group := range groups {
+		labels = append(labels, label)
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Name < group[j].Name
+		})
+	}
+	sort.Strings(labels)
+	return labels, groups
+}
+
+// hasTableStats reports whether any table carries statistics
+func hasTableStats(tables []TableInfo) bool {
+	for _, table := range tables {
+		if table.Stats != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// lastMaintenance returns the later of a manual and an automatic vacuum/analyze time
+func lastMaintenance(manual, auto string) string {
+	// Both are formatted as YYYY-MM-DD HH24:MI:SS, so they compare as strings
+	if auto > manual {
+		return auto + " (auto)"
+	}
+	return manual
+}
+
+// accessSource describes where an effective privilege comes from
+func accessSource(via string, setRole bool) string {
+	if via == "" {
+		via = "direct"
+	}
+	if setRole {
+		via += " (SET ROLE)"
+	}
+	return via
+}
+
+// privilegeObject names the object of an effective privilege, e.g. TABLE shop.orders (price)
+func privilegeObject(priv EffectivePrivilege) string {
+	object := priv.ObjectType + " " + priv.Object
+	if priv.Column != "" {
+		object += " (" + priv.Column + ")"
+	}
+	if priv.Database != "" {
+		object = priv.Database + ": " + object
+	}
+	return object
+}
+
+// effectivePrivilegeGroup is the privileges a user holds on one object from one source
+type effectivePrivilegeGroup struct {
+	Object     string
+	Source     string
+	Privileges []string
+}
+
+// groupEffectivePrivileges combines the privileges on the same object from the same source
+func groupEffectivePrivileges(privileges []EffectivePrivilege) []effectivePrivilegeGroup {
+	var groups []effectivePrivilegeGroup
+	index := make(map[[2]string]int)
+	for _, priv := range privileges {
+		key := [2]string{privilegeObject(priv), accessSource(priv.Via, priv.SetRole)}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, effectivePrivilegeGroup{Object: key[0], Source: key[1]})
+		}
+		groups[i].Privileges = append(groups[i].Privileges, priv.Privilege)
+	}
+	return groups
+}
+
+// rolePath renders the roles from a user down to a role, e.g. app_rw -> app_ro
+func rolePath(role RoleAccess) string {
+	path := strings.Join(role.Path, " -> ")
+	if role.SetRole {
+		path += " (SET ROLE)"
+	}
+	return path
+}
+
+// writerLabel renders a table writer, e.g. app@% INSERT, UPDATE (via app_rw@%)
+func writerLabel(writer TableWriter) string {
+	return fmt.Sprintf("%s %s (%s)", writer.User, strings.Join(writer.Privileges, ", "),
+		accessSource(writer.Via, writer.SetRole))
+}
+
 // MarkdownFormatter formats output as Markdown
 type MarkdownFormatter struct{}
 
@@ -61,7 +263,8 @@ func (f *MarkdownFormatter) Format(info *DatabaseInfo) (string, error) {
 	}
 	
 	// Generate sections list based on actual content
-	sections := f.generateSectionsList(info)
+	diagram := buildERD(info)
+	sections := f.generateSectionsList(info, diagram)
 	if len(sections) > 0 {
 		result.WriteString("## File Structure\n\n")
 		for _, section := range sections {
@@ -82,12 +285,39 @@ func (f *MarkdownFormatter) Format(info *DatabaseInfo) (string, error) {
 		f.formatTables(&result, info.Tables)
 	}
 
+	// Entity relationship diagram, when the tables have foreign keys
+	if len(diagram.Relations) > 0 {
+		result.WriteString("# Entity Relationship Diagram\n\n")
+		result.WriteString("```mermaid\n")
+		diagram.writeMermaid(&result)
+		result.WriteString("```\n\n")
+	}
+
+	// Table statistics
+	if hasTableStats(info.Tables) {
+		result.WriteString("# Table Statistics\n\n")
+		f.formatTableStats(&result, info.Tables, info.DBType)
+	}
+
 	// Views (separate section for view details)
 	if f.hasViews(info.Tables) {
 		result.WriteString("# View info details\n\n")
 		f.formatViewDetails(&result, info.Tables)
 	}
 
+	// Sequences (PostgreSQL)
+	sequences := f.filterTables(info.Tables, "SEQUENCE")
+	if len(sequences) > 0 {
+		result.WriteString("# Sequences\n\n")
+		f.formatSequences(&result, sequences)
+	}
+
+	// User-defined types (PostgreSQL)
+	if len(info.Types) > 0 {
+		result.WriteString("# Types\n\n")
+		f.formatTypes(&result, info.Types)
+	}
+
 	// Stored functions
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
@@ -102,6 +332,24 @@ func (f *MarkdownFormatter) Format(info *DatabaseInfo) (string, error) {
 		f.formatRoutines(&result, procedures)
 	}
 
+	// Triggers
+	if len(info.Triggers) > 0 {
+		result.WriteString("# Triggers\n\n")
+		f.formatTriggers(&result, info.Triggers)
+	}
+
+	// Scheduled events
+	if len(info.Events) > 0 {
+		result.WriteString("# Events\n\n")
+		f.formatEvents(&result, info.Events)
+	}
+
+	// Event triggers (PostgreSQL)
+	if len(info.EventTriggers) > 0 {
+		result.WriteString("# Event Triggers\n\n")
+		f.formatEventTriggers(&result, info.EventTriggers)
+	}
+
 	// Roles
 	if len(info.Roles) > 0 {
 		if info.DBType == "postgres" {
@@ -142,18 +390,73 @@ func (f *MarkdownFormatter) Format(info *DatabaseInfo) (string, error) {
 		f.formatReplicationInfo(&result, info.ReplicationInfo)
 	}
 
+	// Workload
+	if len(info.Workload) > 0 {
+		result.WriteString("# Workload\n\n")
+		f.formatWorkload(&result, info.Workload)
+	}
+
+	// Lint findings
+	if info.Lint != nil {
+		result.WriteString("# Lint\n\n")
+		f.formatFindings(&result, info.Lint)
+	}
+
+	// Security audit findings
+	if info.Security != nil {
+		result.WriteString("# Security Audit\n\n")
+		f.formatFindings(&result, info.Security)
+	}
+
+	// Effective privileges
+	if info.Access != nil {
+		result.WriteString("# Effective Privileges\n\n")
+		f.formatAccess(&result, info.Access)
+	}
+
+	// Upgrade check
+	if info.Upgrade != nil {
+		result.WriteString("# Upgrade Check\n\n")
+		result.WriteString(fmt.Sprintf("Upgrade from %s to %s. Errors are blockers.\n\n", info.Upgrade.Source, info.Upgrade.Target))
+		f.formatFindings(&result, info.Upgrade.Findings)
+	}
+
+	// Migration check
+	if info.Migration != nil {
+		result.WriteString("# Migration Check\n\n")
+		f.formatMigration(&result, info.Migration)
+	}
+
 	return result.String(), nil
 }
 
 func (f *MarkdownFormatter) formatExtensions(result *strings.Builder, extensions []Extension) {
-	result.WriteString("| Name | Version | Description |\n")
-	result.WriteString("|------|---------|-------------|\n")
+	// Extensions are per database when several PostgreSQL databases are collected
+	multiDB := false
+	for _, ext := range extensions {
+		if ext.Database != "" {
+			multiDB = true
+			break
+		}
+	}
+
+	if multiDB {
+		result.WriteString("| Database | Name | Version | Description |\n")
+		result.WriteString("|----------|------|---------|-------------|\n")
+	} else {
+		result.WriteString("| Name | Version | Description |\n")
+		result.WriteString("|------|---------|-------------|\n")
+	}
 	for _, ext := range extensions {
 		desc := ext.Description
 		if desc == "" {
 			desc = "-"
 		}
-		result.WriteString(fmt.Sprintf("| %s | %s | %s |\n", ext.Name, ext.Version, desc))
+		if multiDB {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", ext.Database, ext.Name, ext.Version, desc))
+		} else {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s |\n", ext.Name, ext.Version, desc))
+		}
 	}
 	result.WriteString("\n")
 }
@@ -163,10 +466,12 @@ func (f *MarkdownFormatter) formatTables(result *strings.Builder, tables []Table
 		return
 	}
 
-	// Group tables by database and schema, excluding views
+	partitions := partitionsByParent(tables)
+
+	// Group tables by database and schema, excluding views and sequences
 	dbSchemaMap := make(map[string]map[string][]TableInfo)
 	for _, table := range tables {
-		if table.Type == "BASE TABLE" {
+		if table.Type == "BASE TABLE" || table.Type == "FOREIGN TABLE" {
 			if dbSchemaMap[table.Database] == nil {
 				dbSchemaMap[table.Database] = make(map[string][]TableInfo)
 			}
@@ -205,48 +510,249 @@ func (f *MarkdownFormatter) formatTables(result *strings.Builder, tables []Table
 					result.WriteString(fmt.Sprintf("## %s.%s\n\n", table.Schema, table.Name))
 				}
 
-			if table.Engine != "" {
-				result.WriteString(fmt.Sprintf("- Engine: %s\n", table.Engine))
-			}
-			if table.AutoIncrement > 0 {
-				result.WriteString(fmt.Sprintf("- Auto Increment: %d\n", table.AutoIncrement))
-			}
-			if !table.CreatedAt.IsZero() {
-				result.WriteString(fmt.Sprintf("- Created: %s\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
+				if table.Type == "FOREIGN TABLE" {
+					result.WriteString("- Type: FOREIGN TABLE\n")
+				}
+				if table.Engine != "" {
+					result.WriteString(fmt.Sprintf("- Engine: %s\n", table.Engine))
+				}
+				if table.PartitionKey != "" {
+					result.WriteString(fmt.Sprintf("- Partition Key: %s\n", table.PartitionKey))
+				}
+				if children := partitions[table.Schema+"."+table.Name]; len(children) > 0 {
+					result.WriteString(fmt.Sprintf("- Partitions: %s\n", strings.Join(children, ", ")))
+				}
+				if table.PartitionOf != "" {
+					result.WriteString(fmt.Sprintf("- Partition Of: %s\n", table.PartitionOf))
+					result.WriteString(fmt.Sprintf("- Partition Bound: %s\n", table.PartitionBound))
+				}
+				if label := rowSecurityLabel(table); label != "" {
+					result.WriteString(fmt.Sprintf("- Row Level Security: %s\n", label))
+				}
+				for _, policy := range table.Policies {
+					result.WriteString(fmt.Sprintf("- Policy: %s\n", policySummary(policy)))
+				}
+				if table.AutoIncrement > 0 {
+					result.WriteString(fmt.Sprintf("- Auto Increment: %d\n", table.AutoIncrement))
+				}
+				if !table.CreatedAt.IsZero() {
+					result.WriteString(fmt.Sprintf("- Created: %s\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
+				}
+				if !table.UpdatedAt.IsZero() {
+					result.WriteString(fmt.Sprintf("- Updated: %s\n", table.UpdatedAt.Format("2006-01-02 15:04:05")))
+				}
+				if table.Collation != "" {
+					result.WriteString(fmt.Sprintf("- Collation: %s\n", table.Collation))
+				}
+				if table.Charset != "" {
+					result.WriteString(fmt.Sprintf("- Charset: %s\n", table.Charset))
+				}
+				if table.RowFormat != "" {
+					result.WriteString(fmt.Sprintf("- Row Format: %s\n", table.RowFormat))
+				}
+				if table.Comment != "" {
+					result.WriteString(fmt.Sprintf("- Comment: %s\n", table.Comment))
+				}
+				if table.CreateOptions != "" {
+					result.WriteString(fmt.Sprintf("- Create Options: %s\n", table.CreateOptions))
+				}
+				f.formatTableStructure(result, table)
+
+				if table.DDL != "" {
+					result.WriteString("\n```sql\n")
+					result.WriteString(table.DDL)
+					result.WriteString("\n```\n\n")
+				}
 			}
-			if !table.UpdatedAt.IsZero() {
-				result.WriteString(fmt.Sprintf("- Updated: %s\n", table.UpdatedAt.Format("2006-01-02 15:04:05")))
+		}
+	}
+}
+
+// formatTableStructure writes the columns, indexes, foreign keys and check constraints of a table
+func (f *MarkdownFormatter) formatTableStructure(result *strings.Builder, table TableInfo) {
+	if len(table.Columns) > 0 {
+		result.WriteString("\n### Columns\n\n")
+		result.WriteString("| Column | Type | Nullable | Default | Extra | Comment |\n")
+		result.WriteString("|--------|------|----------|---------|-------|---------|\n")
+		for _, column := range table.Columns {
+			nullable := "NO"
+			if column.Nullable {
+				nullable = "YES"
 			}
-			if table.Collation != "" {
-				result.WriteString(fmt.Sprintf("- Collation: %s\n", table.Collation))
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n", markdownCell(column.Name),
+				markdownCell(column.Type), nullable, markdownCell(column.Default),
+				markdownCell(columnExtra(column, table.Collation)), markdownCell(column.Comment)))
+		}
+	}
+
+	if len(table.Indexes) > 0 {
+		result.WriteString("\n### Indexes\n\n")
+		result.WriteString("| Index | Columns | Unique | Method |\n")
+		result.WriteString("|-------|---------|--------|--------|\n")
+		for _, index := range table.Indexes {
+			unique := "NO"
+			switch {
+			case index.Primary:
+				unique = "PRIMARY KEY"
+			case index.Unique:
+				unique = "YES"
 			}
-			if table.Charset != "" {
-				result.WriteString(fmt.Sprintf("- Charset: %s\n", table.Charset))
+			columns := strings.Join(index.Columns, ", ")
+			if index.Predicate != "" {
+				columns += " WHERE " + index.Predicate
 			}
-			if table.RowFormat != "" {
-				result.WriteString(fmt.Sprintf("- Row Format: %s\n", table.RowFormat))
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", markdownCell(index.Name), markdownCell(columns),
+				unique, index.Method))
+		}
+	}
+
+	if len(table.ForeignKeys) > 0 {
+		result.WriteString("\n### Foreign Keys\n\n")
+		result.WriteString("| Foreign Key | Columns | References | On Update | On Delete |\n")
+		result.WriteString("|-------------|---------|------------|-----------|-----------|\n")
+		for _, fk := range table.ForeignKeys {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s.%s (%s) | %s | %s |\n", markdownCell(fk.Name),
+				markdownCell(strings.Join(fk.Columns, ", ")), markdownCell(fk.RefSchema), markdownCell(fk.RefTable),
+				markdownCell(strings.Join(fk.RefColumns, ", ")), fk.OnUpdate, fk.OnDelete))
+		}
+	}
+
+	if len(table.CheckConstraints) > 0 {
+		result.WriteString("\n### Check Constraints\n\n")
+		result.WriteString("| Check | Expression |\n")
+		result.WriteString("|-------|------------|\n")
+		for _, check := range table.CheckConstraints {
+			expression := check.Expression
+			if check.NotEnforced {
+				expression += " (NOT ENFORCED)"
 			}
-			if table.Comment != "" {
-				result.WriteString(fmt.Sprintf("- Comment: %s\n", table.Comment))
+			result.WriteString(fmt.Sprintf("| %s | %s |\n", markdownCell(check.Name), markdownCell(expression)))
+		}
+	}
+}
+
+// columnExtra describes the properties of a column that have no column of their own in the
+// Markdown column table. The collation is left out when it is the table default.
+func columnExtra(column ColumnInfo, tableCollation string) string {
+	var extra []string
+	if column.AutoIncrement {
+		extra = append(extra, "AUTO_INCREMENT")
+	}
+	if column.Identity != "" {
+		extra = append(extra, fmt.Sprintf("GENERATED %s AS IDENTITY", column.Identity))
+	}
+	if column.Generated != "" {
+		extra = append(extra, fmt.Sprintf("GENERATED ALWAYS AS (%s)", column.Generated))
+	}
+	if column.OnUpdate != "" {
+		extra = append(extra, "ON UPDATE "+column.OnUpdate)
+	}
+	if column.Collation != "" && column.Collation != tableCollation {
+		extra = append(extra, "COLLATE "+column.Collation)
+	}
+	return strings.Join(extra, ", ")
+}
+
+// markdownCell escapes a value for a Markdown table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ").Replace(s)
+}
+
+func (f *MarkdownFormatter) formatTableStats(result *strings.Builder, tables []TableInfo, dbType string) {
+	labels, groups := tableStatsGroups(tables)
+	for _, label := range labels {
+		result.WriteString(fmt.Sprintf("## %s\n\n", label))
+		if dbType == "postgres" {
+			f.formatPostgresTableStats(result, groups[label])
+			continue
+		}
+		result.WriteString("| Table | Rows (est.) | Data | Indexes | Free |\n")
+		result.WriteString("|-------|-------------|------|---------|------|\n")
+		for _, table := range groups[label] {
+			stats := table.Stats
+			result.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s |\n", table.Name, stats.RowEstimate,
+				formatBytes(stats.DataLength), formatBytes(stats.IndexLength), formatBytes(stats.DataFree)))
+		}
+		result.WriteString("\n")
+
+		var indexes, histograms strings.Builder
+		for _, table := range groups[label] {
+			for _, index := range table.Stats.Indexes {
+				unique := "NO"
+				if index.Unique {
+					unique = "YES"
+				}
+				size := ""
+				if index.SizePages > 0 {
+					size = fmt.Sprintf("%d / %d", index.SizePages, index.LeafPages)
+				}
+				indexes.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d | %s |\n", table.Name, index.Name,
+					strings.Join(index.Columns, ", "), unique, index.Cardinality, size))
 			}
-			if table.CreateOptions != "" {
-				result.WriteString(fmt.Sprintf("- Create Options: %s\n", table.CreateOptions))
+			for _, histogram := range table.Stats.Histograms {
+				histograms.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %.2f | %s |\n", table.Name, histogram.Column,
+					histogram.Type, histogram.Buckets, histogram.SamplingRate, histogram.LastUpdated))
 			}
+		}
+		if indexes.Len() > 0 {
+			result.WriteString("### Indexes\n\n")
+			result.WriteString("| Table | Index | Columns | Unique | Cardinality | Pages (total / leaf) |\n")
+			result.WriteString("|-------|-------|---------|--------|-------------|----------------------|\n")
+			result.WriteString(indexes.String())
+			result.WriteString("\n")
+		}
+		if histograms.Len() > 0 {
+			result.WriteString("### Histograms\n\n")
+			result.WriteString("| Table | Column | Type | Buckets | Sampling Rate | Last Updated |\n")
+			result.WriteString("|-------|--------|------|---------|---------------|--------------|\n")
+			result.WriteString(histograms.String())
+			result.WriteString("\n")
+		}
+	}
+}
+
+func (f *MarkdownFormatter) formatPostgresTableStats(result *strings.Builder, tables []TableInfo) {
+	result.WriteString("| Table | Rows (est.) | Live | Dead | Seq Scans | Index Scans | Table Size | TOAST | Indexes | Total | Last Vacuum | Last Analyze |\n")
+	result.WriteString("|-------|-------------|------|------|-----------|-------------|------------|-------|---------|-------|-------------|--------------|\n")
+	for _, table := range tables {
+		stats := table.Stats
+		result.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %s | %s | %s | %s | %s | %s |\n", table.Name,
+			stats.RowEstimate, stats.LiveTuples, stats.DeadTuples, stats.SeqScans, stats.IndexScans,
+			formatBytes(stats.DataLength), formatBytes(stats.ToastLength), formatBytes(stats.IndexLength), formatBytes(stats.TotalLength),
+			lastMaintenance(stats.LastVacuum, stats.LastAutovacuum), lastMaintenance(stats.LastAnalyze, stats.LastAutoanalyze)))
+	}
+	result.WriteString("\n")
 
-			if table.DDL != "" {
-				result.WriteString("\n```sql\n")
-				result.WriteString(table.DDL)
-				result.WriteString("\n```\n\n")
+	var indexes strings.Builder
+	for _, table := range tables {
+		for _, index := range table.Stats.Indexes {
+			unique, unused := "NO", ""
+			if index.Unique {
+				unique = "YES"
 			}
+			if index.Unused {
+				unused = "YES"
+			}
+			indexes.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d | %s | %s |\n", table.Name, index.Name,
+				strings.Join(index.Columns, ", "), unique, index.Scans, formatBytes(index.SizeBytes), unused))
 		}
 	}
+	if indexes.Len() > 0 {
+		result.WriteString("### Indexes\n\n")
+		result.WriteString("| Table | Index | Columns | Unique | Scans | Size | Unused |\n")
+		result.WriteString("|-------|-------|---------|--------|-------|------|--------|\n")
+		result.WriteString(indexes.String())
+		result.WriteString("\n")
 	}
 }
 
 func (f *MarkdownFormatter) formatViewDetails(result *strings.Builder, tables []TableInfo) {
 	for _, table := range tables {
-		if table.Type == "VIEW" && table.DDL != "" {
+		if (table.Type == "VIEW" || table.Type == "MATERIALIZED VIEW") && table.DDL != "" {
 			result.WriteString(fmt.Sprintf("## %s.%s\n\n", table.Schema, table.Name))
+			if table.Comment != "" {
+				result.WriteString(fmt.Sprintf("- Comment: %s\n\n", table.Comment))
+			}
 			result.WriteString("```sql\n")
 			result.WriteString(table.DDL)
 			result.WriteString("\n```\n\n")
@@ -254,6 +760,42 @@ func (f *MarkdownFormatter) formatViewDetails(result *strings.Builder, tables []
 	}
 }
 
+func (f *MarkdownFormatter) formatSequences(result *strings.Builder, sequences []TableInfo) {
+	for _, sequence := range sequences {
+		result.WriteString(fmt.Sprintf("## %s.%s\n\n", sequence.Schema, sequence.Name))
+		if sequence.DDL != "" {
+			result.WriteString("```sql\n")
+			result.WriteString(sequence.DDL)
+			result.WriteString("\n```\n\n")
+		}
+	}
+}
+
+func (f *MarkdownFormatter) formatTypes(result *strings.Builder, types []TypeInfo) {
+	for _, typeInfo := range types {
+		result.WriteString(fmt.Sprintf("## %s\n\n", qualifiedName(typeInfo.Database, typeInfo.Schema, typeInfo.Name)))
+		result.WriteString(fmt.Sprintf("- Kind: %s\n", typeInfo.Kind))
+		if typeInfo.Owner != "" {
+			result.WriteString(fmt.Sprintf("- Owner: %s\n", typeInfo.Owner))
+		}
+		if len(typeInfo.Labels) > 0 {
+			result.WriteString(fmt.Sprintf("- Labels: %s\n", strings.Join(typeInfo.Labels, ", ")))
+		}
+		if typeInfo.BaseType != "" {
+			result.WriteString(fmt.Sprintf("- Base Type: %s\n", typeInfo.BaseType))
+		}
+		if typeInfo.Comment != "" {
+			result.WriteString(fmt.Sprintf("- Comment: %s\n", typeInfo.Comment))
+		}
+		if typeInfo.DDL != "" {
+			result.WriteString("\n```sql\n")
+			result.WriteString(typeInfo.DDL)
+			result.WriteString("\n```\n")
+		}
+		result.WriteString("\n")
+	}
+}
+
 func (f *MarkdownFormatter) formatVariables(result *strings.Builder, variables []Variable) {
 	// Check if source information is available
 	hasSource := false
@@ -285,7 +827,7 @@ func (f *MarkdownFormatter) formatVariables(result *strings.Builder, variables [
 }
 
 // generateSectionsList creates a list of sections that will be included in the output
-func (f *MarkdownFormatter) generateSectionsList(info *DatabaseInfo) []string {
+func (f *MarkdownFormatter) generateSectionsList(info *DatabaseInfo, diagram *erd) []string {
 	var sections []string
 
 	dbLabel := "MySQL"
@@ -299,9 +841,21 @@ func (f *MarkdownFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(info.Tables) > 0 {
 		sections = append(sections, "Tables - Database tables with metadata and DDL definitions")
 	}
+	if len(diagram.Relations) > 0 {
+		sections = append(sections, "Entity Relationship Diagram - Mermaid diagram of the tables and their foreign keys")
+	}
+	if hasTableStats(info.Tables) {
+		sections = append(sections, "Table Statistics - Row estimates, sizes, index usage and statistics")
+	}
 	if f.hasViews(info.Tables) {
 		sections = append(sections, "View Details - Database views with their definitions")
 	}
+	if len(f.filterTables(info.Tables, "SEQUENCE")) > 0 {
+		sections = append(sections, "Sequences - PostgreSQL sequences with their current values")
+	}
+	if len(info.Types) > 0 {
+		sections = append(sections, "Types - User-defined types, domains and collations")
+	}
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
 		sections = append(sections, "Stored Functions - User-defined functions with their definitions")
@@ -310,6 +864,15 @@ func (f *MarkdownFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(procedures) > 0 {
 		sections = append(sections, "Stored Procedures - User-defined procedures with their definitions")
 	}
+	if len(info.Triggers) > 0 {
+		sections = append(sections, "Triggers - Table triggers with their definitions")
+	}
+	if len(info.Events) > 0 {
+		sections = append(sections, "Events - Scheduled events with their schedules and definitions")
+	}
+	if len(info.EventTriggers) > 0 {
+		sections = append(sections, "Event Triggers - Database-wide DDL event triggers")
+	}
 	if len(info.Roles) > 0 {
 		sections = append(sections, "User Roles - Role definitions and assignments")
 	}
@@ -328,6 +891,24 @@ func (f *MarkdownFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if info.ReplicationInfo != nil {
 		sections = append(sections, "Replication Info - Replication configuration and status")
 	}
+	if len(info.Workload) > 0 {
+		sections = append(sections, "Workload - Top statements by total execution time")
+	}
+	if info.Lint != nil {
+		sections = append(sections, "Lint - Schema lint findings by severity")
+	}
+	if info.Security != nil {
+		sections = append(sections, "Security Audit - Account and privilege findings by severity")
+	}
+	if info.Access != nil {
+		sections = append(sections, "Effective Privileges - Privileges of each user through role grants, and table writers")
+	}
+	if info.Upgrade != nil {
+		sections = append(sections, "Upgrade Check - Blockers and warnings for the target version")
+	}
+	if info.Migration != nil {
+		sections = append(sections, "Migration Check - How tables, views and stored programs translate to the target database")
+	}
 
 	return sections
 }
@@ -359,7 +940,7 @@ func (f *MarkdownFormatter) formatUsers(result *strings.Builder, users []UserAcc
 
 func (f *MarkdownFormatter) formatRoutines(result *strings.Builder, routines []RoutineInfo) {
 	for _, routine := range routines {
-		result.WriteString(fmt.Sprintf("## %s.%s\n\n", routine.Schema, routine.Name))
+		result.WriteString(fmt.Sprintf("## %s\n\n", qualifiedName(routine.Database, routine.Schema, routine.Name)))
 		result.WriteString(fmt.Sprintf("- Specific Name: %s\n", routine.Name))
 		result.WriteString("- Routine Catalog: def\n")
 		if routine.Type == "FUNCTION" && routine.Returns != "" {
@@ -376,6 +957,9 @@ func (f *MarkdownFormatter) formatRoutines(result *strings.Builder, routines []R
 		if routine.SecurityType != "" {
 			result.WriteString(fmt.Sprintf("- Security Type: %s\n", routine.SecurityType))
 		}
+		if routine.Comment != "" {
+			result.WriteString(fmt.Sprintf("- Comment: %s\n", routine.Comment))
+		}
 		if !routine.Created.IsZero() {
 			result.WriteString(fmt.Sprintf("- Created: %s\n", routine.Created.Format("2006-01-02 15:04:05")))
 		}
@@ -392,6 +976,100 @@ func (f *MarkdownFormatter) formatRoutines(result *strings.Builder, routines []R
 	}
 }
 
+func (f *MarkdownFormatter) formatTriggers(result *strings.Builder, triggers []TriggerInfo) {
+	for _, trigger := range triggers {
+		result.WriteString(fmt.Sprintf("## %s.%s\n\n", trigger.Schema, trigger.Name))
+		result.WriteString(fmt.Sprintf("- Table: %s.%s\n", trigger.Schema, trigger.Table))
+		result.WriteString(fmt.Sprintf("- Timing: %s %s\n", trigger.Timing, trigger.Event))
+		if trigger.Definer != "" {
+			result.WriteString(fmt.Sprintf("- Definer: %s\n", trigger.Definer))
+		}
+		if trigger.SQLMode != "" {
+			result.WriteString(fmt.Sprintf("- SQL Mode: %s\n", trigger.SQLMode))
+		}
+		if !trigger.Created.IsZero() {
+			result.WriteString(fmt.Sprintf("- Created: %s\n", trigger.Created.Format("2006-01-02 15:04:05")))
+		}
+
+		ddl := trigger.DDL
+		if ddl == "" {
+			ddl = trigger.Statement
+		}
+		if ddl != "" {
+			result.WriteString("\n```sql\n")
+			result.WriteString(ddl)
+			result.WriteString("\n```\n\n")
+		} else {
+			result.WriteString("\n")
+		}
+	}
+}
+
+func (f *MarkdownFormatter) formatEvents(result *strings.Builder, events []EventInfo) {
+	for _, event := range events {
+		result.WriteString(fmt.Sprintf("## %s.%s\n\n", event.Schema, event.Name))
+		result.WriteString(fmt.Sprintf("- Type: %s\n", event.Type))
+		if event.Schedule != "" {
+			result.WriteString(fmt.Sprintf("- Schedule: %s\n", event.Schedule))
+		}
+		result.WriteString(fmt.Sprintf("- Status: %s\n", event.Status))
+		if event.OnCompletion != "" {
+			result.WriteString(fmt.Sprintf("- On Completion: %s\n", event.OnCompletion))
+		}
+		if event.Definer != "" {
+			result.WriteString(fmt.Sprintf("- Definer: %s\n", event.Definer))
+		}
+		if event.TimeZone != "" {
+			result.WriteString(fmt.Sprintf("- Time Zone: %s\n", event.TimeZone))
+		}
+		if event.SQLMode != "" {
+			result.WriteString(fmt.Sprintf("- SQL Mode: %s\n", event.SQLMode))
+		}
+		if event.Comment != "" {
+			result.WriteString(fmt.Sprintf("- Comment: %s\n", event.Comment))
+		}
+		if !event.LastExecuted.IsZero() {
+			result.WriteString(fmt.Sprintf("- Last Executed: %s\n", event.LastExecuted.Format("2006-01-02 15:04:05")))
+		}
+
+		ddl := event.DDL
+		if ddl == "" {
+			ddl = event.Body
+		}
+		if ddl != "" {
+			result.WriteString("\n```sql\n")
+			result.WriteString(ddl)
+			result.WriteString("\n```\n\n")
+		} else {
+			result.WriteString("\n")
+		}
+	}
+}
+
+func (f *MarkdownFormatter) formatEventTriggers(result *strings.Builder, triggers []EventTriggerInfo) {
+	for _, trigger := range triggers {
+		if trigger.Database != "" {
+			result.WriteString(fmt.Sprintf("## %s.%s\n\n", trigger.Database, trigger.Name))
+		} else {
+			result.WriteString(fmt.Sprintf("## %s\n\n", trigger.Name))
+		}
+		result.WriteString(fmt.Sprintf("- Event: %s\n", trigger.Event))
+		if len(trigger.Tags) > 0 {
+			result.WriteString(fmt.Sprintf("- Tags: %s\n", strings.Join(trigger.Tags, ", ")))
+		}
+		result.WriteString(fmt.Sprintf("- Function: %s\n", trigger.Function))
+		result.WriteString(fmt.Sprintf("- Owner: %s\n", trigger.Owner))
+		result.WriteString(fmt.Sprintf("- Enabled: %s\n", trigger.Enabled))
+		if trigger.DDL != "" {
+			result.WriteString("\n```sql\n")
+			result.WriteString(trigger.DDL)
+			result.WriteString("\n```\n\n")
+		} else {
+			result.WriteString("\n")
+		}
+	}
+}
+
 func (f *MarkdownFormatter) formatRoles(result *strings.Builder, roles []UserRole) {
 	for _, role := range roles {
 		result.WriteString(fmt.Sprintf("## %s@%s\n\n", role.RoleName, role.RoleHost))
@@ -438,6 +1116,122 @@ func (f *MarkdownFormatter) formatComponents(result *strings.Builder, components
 	result.WriteString("\n")
 }
 
+func (f *MarkdownFormatter) formatFindings(result *strings.Builder, findings []Finding) {
+	result.WriteString(fmt.Sprintf("%s\n\n", findingsSummary(findings)))
+	if len(findings) == 0 {
+		return
+	}
+	result.WriteString("| Severity | Rule | Object | Message |\n")
+	result.WriteString("|----------|------|--------|---------|\n")
+	for _, finding := range findings {
+		result.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", strings.ToUpper(string(finding.Severity)),
+			finding.Rule, finding.Object, strings.ReplaceAll(finding.Message, "|", "\\|")))
+	}
+	result.WriteString("\n")
+}
+
+func (f *MarkdownFormatter) formatMigration(result *strings.Builder, migration *MigrationReport) {
+	result.WriteString(fmt.Sprintf("Migration from %s to %s. Errors need a manual rewrite, warnings change behavior.\n\n", migration.Source, migration.Target))
+
+	result.WriteString(fmt.Sprintf("## Objects\n\n%s\n\n", migrationSummary(migration.Objects)))
+	if len(migration.Objects) > 0 {
+		result.WriteString("| Object | Status |\n")
+		result.WriteString("|--------|--------|\n")
+		for _, object := range migration.Objects {
+			result.WriteString(fmt.Sprintf("| %s | %s |\n", object.Object, object.Status))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(migration.TypeMappings) > 0 {
+		result.WriteString("## Type Mappings\n\n")
+		result.WriteString("| MySQL Type | PostgreSQL Type | Columns |\n")
+		result.WriteString("|------------|-----------------|---------|\n")
+		for _, mapping := range migration.TypeMappings {
+			result.WriteString(fmt.Sprintf("| %s | %s | %d |\n", mapping.From, mapping.To, mapping.Columns))
+		}
+		result.WriteString("\n")
+	}
+
+	result.WriteString("## Findings\n\n")
+	f.formatFindings(result, migration.Findings)
+}
+
+func (f *MarkdownFormatter) formatAccess(result *strings.Builder, access *AccessReport) {
+	for _, user := range access.Users {
+		result.WriteString(fmt.Sprintf("## %s\n\n", user.User))
+		if user.Superuser {
+			result.WriteString("- Superuser: all privileges\n")
+		}
+		if len(user.Roles) > 0 {
+			result.WriteString("- Roles:\n")
+			for _, role := range user.Roles {
+				result.WriteString(fmt.Sprintf("  - %s\n", rolePath(role)))
+			}
+		}
+		if user.Superuser || len(user.Roles) > 0 {
+			result.WriteString("\n")
+		}
+
+		if len(user.Privileges) == 0 {
+			if !user.Superuser {
+				result.WriteString("No privileges.\n\n")
+			}
+			continue
+		}
+		result.WriteString("| Object | Privileges | Via |\n")
+		result.WriteString("|--------|------------|-----|\n")
+		for _, group := range groupEffectivePrivileges(user.Privileges) {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s |\n", group.Object, strings.Join(group.Privileges, ", "), group.Source))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(access.Writers) > 0 {
+		result.WriteString("## Table Writers\n\n")
+		result.WriteString("Users that can insert, update or delete rows of each table.\n\n")
+		result.WriteString("| Table | Writers |\n")
+		result.WriteString("|-------|---------|\n")
+		for _, table := range access.Writers {
+			labels := make([]string, 0, len(table.Writers))
+			for _, writer := range table.Writers {
+				labels = append(labels, writerLabel(writer))
+			}
+			if len(labels) == 0 {
+				labels = append(labels, "-")
+			}
+			result.WriteString(fmt.Sprintf("| %s | %s |\n", table.Table, strings.Join(labels, "<br>")))
+		}
+		result.WriteString("\n")
+	}
+}
+
+func (f *MarkdownFormatter) formatWorkload(result *strings.Builder, workload []StatementDigest) {
+	result.WriteString("Top statements by total execution time.\n\n")
+	for i, digest := range workload {
+		result.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, digest.Digest))
+		if digest.Database != "" {
+			result.WriteString(fmt.Sprintf("- Database: %s\n", digest.Database))
+		}
+		if digest.User != "" {
+			result.WriteString(fmt.Sprintf("- User: %s\n", digest.User))
+		}
+		result.WriteString(fmt.Sprintf("- Calls: %d\n", digest.Calls))
+		result.WriteString(fmt.Sprintf("- Total Time: %.3f ms\n", digest.TotalTimeMs))
+		result.WriteString(fmt.Sprintf("- Mean Time: %.3f ms\n", digest.MeanTimeMs))
+		if digest.RowsExamined > 0 {
+			result.WriteString(fmt.Sprintf("- Rows Examined: %d\n", digest.RowsExamined))
+		}
+		result.WriteString(fmt.Sprintf("- Rows Returned: %d\n", digest.RowsReturned))
+		if digest.BlocksHit > 0 || digest.BlocksRead > 0 {
+			result.WriteString(fmt.Sprintf("- Shared Blocks: %d hit, %d read\n", digest.BlocksHit, digest.BlocksRead))
+		}
+		result.WriteString("\n```sql\n")
+		result.WriteString(digest.Query)
+		result.WriteString("\n```\n\n")
+	}
+}
+
 func (f *MarkdownFormatter) formatReplicationInfo(result *strings.Builder, replication *ReplicationInfo) {
 	if replication.ReplicationStatus != nil {
 		result.WriteString("## Basic Replication Status\n\n")
@@ -455,11 +1249,82 @@ func (f *MarkdownFormatter) formatReplicationInfo(result *strings.Builder, repli
 		result.WriteString(fmt.Sprintf("- **GTID Mode**: %s\n", status.GTIDMode))
 		result.WriteString("\n")
 	}
+
+	// PostgreSQL
+	if replication.Role != "" {
+		result.WriteString("## Server Role\n\n")
+		result.WriteString(fmt.Sprintf("- **Role**: %s\n\n", replication.Role))
+	}
+
+	if len(replication.Standbys) > 0 {
+		result.WriteString("## Standbys\n\n")
+		result.WriteString("| Application | Client | State | Sync State | Sent LSN | Replay LSN | Write Lag | Flush Lag | Replay Lag |\n")
+		result.WriteString("|-------------|--------|-------|------------|----------|------------|-----------|-----------|------------|\n")
+		for _, standby := range replication.Standbys {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				standby.ApplicationName, standby.ClientAddr, standby.State, standby.SyncState,
+				standby.SentLSN, standby.ReplayLSN, standby.WriteLag, standby.FlushLag, standby.ReplayLag))
+		}
+		result.WriteString("\n")
+	}
+
+	if len(replication.ReplicationSlots) > 0 {
+		result.WriteString("## Replication Slots\n\n")
+		result.WriteString("| Slot | Type | Plugin | Database | Active | WAL Status | Retained WAL |\n")
+		result.WriteString("|------|------|--------|----------|--------|------------|--------------|\n")
+		for _, slot := range replication.ReplicationSlots {
+			result.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %t | %s | %s |\n",
+				slot.Name, slot.Type, slot.Plugin, slot.Database, slot.Active, slot.WALStatus, slot.RetainedWAL))
+		}
+		result.WriteString("\n")
+		for _, slot := range replication.ReplicationSlots {
+			if !slot.Active {
+				result.WriteString(fmt.Sprintf("> **Warning**: inactive slot `%s` retains %s of WAL\n\n", slot.Name, slot.RetainedWAL))
+			}
+		}
+	}
+
+	if receiver := replication.WALReceiver; receiver != nil {
+		result.WriteString("## WAL Receiver\n\n")
+		result.WriteString(fmt.Sprintf("- **Status**: %s\n", receiver.Status))
+		result.WriteString(fmt.Sprintf("- **Sender**: %s:%d\n", receiver.SenderHost, receiver.SenderPort))
+		if receiver.SlotName != "" {
+			result.WriteString(fmt.Sprintf("- **Slot**: %s\n", receiver.SlotName))
+		}
+		result.WriteString(fmt.Sprintf("- **Flushed LSN**: %s\n", receiver.FlushedLSN))
+		result.WriteString(fmt.Sprintf("- **Latest End LSN**: %s\n", receiver.LatestEndLSN))
+		result.WriteString(fmt.Sprintf("- **Last Message Received**: %s\n", receiver.LastMsgReceiptTime))
+		result.WriteString("\n")
+	}
+
+	if len(replication.Publications) > 0 {
+		result.WriteString("## Publications\n\n")
+		for _, pub := range replication.Publications {
+			result.WriteString(fmt.Sprintf("### %s\n\n", databaseObjectName(pub.Database, pub.Name)))
+			result.WriteString(fmt.Sprintf("- Owner: %s\n", pub.Owner))
+			result.WriteString(fmt.Sprintf("- Operations: %s\n", strings.Join(pub.Operations, ", ")))
+			result.WriteString("\n```sql\n")
+			result.WriteString(pub.DDL)
+			result.WriteString("\n```\n\n")
+		}
+	}
+
+	if len(replication.Subscriptions) > 0 {
+		result.WriteString("## Subscriptions\n\n")
+		for _, sub := range replication.Subscriptions {
+			result.WriteString(fmt.Sprintf("### %s\n\n", databaseObjectName(sub.Database, sub.Name)))
+			result.WriteString(fmt.Sprintf("- Owner: %s\n", sub.Owner))
+			result.WriteString(fmt.Sprintf("- Enabled: %t\n", sub.Enabled))
+			result.WriteString("\n```sql\n")
+			result.WriteString(sub.DDL)
+			result.WriteString("\n```\n\n")
+		}
+	}
 }
 
 func (f *MarkdownFormatter) hasViews(tables []TableInfo) bool {
 	for _, table := range tables {
-		if table.Type == "VIEW" {
+		if table.Type == "VIEW" || table.Type == "MATERIALIZED VIEW" {
 			return true
 		}
 	}
@@ -476,11 +1341,14 @@ func (f *MarkdownFormatter) filterRoutines(routines []RoutineInfo, routineType s
 	return filtered
 }
 
-func (f *MarkdownFormatter) filterTables(tables []TableInfo, tableType string) []TableInfo {
+func (f *MarkdownFormatter) filterTables(tables []TableInfo, tableTypes ...string) []TableInfo {
 	var filtered []TableInfo
 	for _, table := range tables {
-		if table.Type == tableType {
-			filtered = append(filtered, table)
+		for _, tableType := range tableTypes {
+			if table.Type == tableType {
+				filtered = append(filtered, table)
+				break
+			}
 		}
 	}
 	return filtered
@@ -635,9 +1503,10 @@ func (f *XMLFormatter) Format(info *DatabaseInfo) (string, error) {
 		result.WriteString("  </variables>\n")
 	}
 
-	// Tables (BASE TABLE only)
-	baseTables := f.filterTables(info.Tables, "BASE TABLE")
+	// Tables (base and foreign tables)
+	baseTables := f.filterTables(info.Tables, "BASE TABLE", "FOREIGN TABLE")
 	if len(baseTables) > 0 {
+		partitions := partitionsByParent(info.Tables)
 		result.WriteString("  <tables>\n")
 		
 		// Group by database if multiple databases
@@ -668,39 +1537,146 @@ func (f *XMLFormatter) Format(info *DatabaseInfo) (string, error) {
 					result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(table.Database)))
 				}
 				result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(table.Schema), f.escapeXML(table.Name)))
+				if table.Type == "FOREIGN TABLE" {
+					result.WriteString("      <type>FOREIGN TABLE</type>\n")
+				}
 				result.WriteString(fmt.Sprintf("      <engine>%s</engine>\n", f.escapeXML(table.Engine)))
-			if table.AutoIncrement > 0 {
-				result.WriteString(fmt.Sprintf("      <auto_increment>%d</auto_increment>\n", table.AutoIncrement))
-			}
-			if !table.CreatedAt.IsZero() {
-				result.WriteString(fmt.Sprintf("      <created>%s</created>\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
-			}
-			result.WriteString(fmt.Sprintf("      <collation>%s</collation>\n", f.escapeXML(table.Collation)))
-			if table.Charset != "" {
-				result.WriteString(fmt.Sprintf("      <charset>%s</charset>\n", f.escapeXML(table.Charset)))
-			}
-			if table.RowFormat != "" {
-				result.WriteString(fmt.Sprintf("      <row_format>%s</row_format>\n", f.escapeXML(table.RowFormat)))
-			}
-			if table.DDL != "" {
-				result.WriteString("      <ddl><![CDATA[")
-				result.WriteString(table.DDL)
-				result.WriteString("]]></ddl>\n")
-			}
-			result.WriteString("    </table>\n")
+				if table.PartitionKey != "" {
+					result.WriteString(fmt.Sprintf("      <partition_key>%s</partition_key>\n", f.escapeXML(table.PartitionKey)))
+				}
+				if children := partitions[table.Schema+"."+table.Name]; len(children) > 0 {
+					result.WriteString("      <partitions>\n")
+					for _, child := range children {
+						result.WriteString(fmt.Sprintf("        <partition>%s</partition>\n", f.escapeXML(child)))
+					}
+					result.WriteString("      </partitions>\n")
+				}
+				if table.PartitionOf != "" {
+					result.WriteString(fmt.Sprintf("      <partition_of>%s</partition_of>\n", f.escapeXML(table.PartitionOf)))
+					result.WriteString(fmt.Sprintf("      <partition_bound>%s</partition_bound>\n", f.escapeXML(table.PartitionBound)))
+				}
+				if label := rowSecurityLabel(table); label != "" {
+					result.WriteString(fmt.Sprintf("      <row_security>%s</row_security>\n", label))
+				}
+				if len(table.Policies) > 0 {
+					result.WriteString("      <policies>\n")
+					for _, policy := range table.Policies {
+						result.WriteString("        <policy>\n")
+						result.WriteString(fmt.Sprintf("          <name>%s</name>\n", f.escapeXML(policy.Name)))
+						result.WriteString(fmt.Sprintf("          <command>%s</command>\n", policy.Command))
+						result.WriteString(fmt.Sprintf("          <permissive>%s</permissive>\n", policy.Permissive))
+						for _, role := range policy.Roles {
+							result.WriteString(fmt.Sprintf("          <role>%s</role>\n", f.escapeXML(role)))
+						}
+						if policy.Using != "" {
+							result.WriteString(fmt.Sprintf("          <using>%s</using>\n", f.escapeXML(policy.Using)))
+						}
+						if policy.WithCheck != "" {
+							result.WriteString(fmt.Sprintf("          <with_check>%s</with_check>\n", f.escapeXML(policy.WithCheck)))
+						}
+						result.WriteString("        </policy>\n")
+					}
+					result.WriteString("      </policies>\n")
+				}
+				if table.AutoIncrement > 0 {
+					result.WriteString(fmt.Sprintf("      <auto_increment>%d</auto_increment>\n", table.AutoIncrement))
+				}
+				if !table.CreatedAt.IsZero() {
+					result.WriteString(fmt.Sprintf("      <created>%s</created>\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
+				}
+				result.WriteString(fmt.Sprintf("      <collation>%s</collation>\n", f.escapeXML(table.Collation)))
+				if table.Charset != "" {
+					result.WriteString(fmt.Sprintf("      <charset>%s</charset>\n", f.escapeXML(table.Charset)))
+				}
+				if table.RowFormat != "" {
+					result.WriteString(fmt.Sprintf("      <row_format>%s</row_format>\n", f.escapeXML(table.RowFormat)))
+				}
+				f.formatTableStructure(&result, table)
+				if table.DDL != "" {
+					result.WriteString("      <ddl><![CDATA[")
+					result.WriteString(table.DDL)
+					result.WriteString("]]></ddl>\n")
+				}
+				result.WriteString("    </table>\n")
 			}
 		}
 		result.WriteString("  </tables>\n")
 	}
 
+	// Table statistics
+	if hasTableStats(info.Tables) {
+		result.WriteString("  <table_statistics>\n")
+		labels, groups := tableStatsGroups(info.Tables)
+		for _, label := range labels {
+			for _, table := range groups[label] {
+				stats := table.Stats
+				result.WriteString("    <table>\n")
+				result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(label), f.escapeXML(table.Name)))
+				result.WriteString(fmt.Sprintf("      <row_estimate>%d</row_estimate>\n", stats.RowEstimate))
+				result.WriteString(fmt.Sprintf("      <data_length>%d</data_length>\n", stats.DataLength))
+				result.WriteString(fmt.Sprintf("      <index_length>%d</index_length>\n", stats.IndexLength))
+				result.WriteString(fmt.Sprintf("      <data_free>%d</data_free>\n", stats.DataFree))
+				if info.DBType == "postgres" {
+					result.WriteString(fmt.Sprintf("      <toast_length>%d</toast_length>\n", stats.ToastLength))
+					result.WriteString(fmt.Sprintf("      <total_length>%d</total_length>\n", stats.TotalLength))
+					result.WriteString(fmt.Sprintf("      <live_tuples>%d</live_tuples>\n", stats.LiveTuples))
+					result.WriteString(fmt.Sprintf("      <dead_tuples>%d</dead_tuples>\n", stats.DeadTuples))
+					result.WriteString(fmt.Sprintf("      <seq_scans>%d</seq_scans>\n", stats.SeqScans))
+					result.WriteString(fmt.Sprintf("      <index_scans>%d</index_scans>\n", stats.IndexScans))
+					if vacuum := lastMaintenance(stats.LastVacuum, stats.LastAutovacuum); vacuum != "" {
+						result.WriteString(fmt.Sprintf("      <last_vacuum>%s</last_vacuum>\n", vacuum))
+					}
+					if analyze := lastMaintenance(stats.LastAnalyze, stats.LastAutoanalyze); analyze != "" {
+						result.WriteString(fmt.Sprintf("      <last_analyze>%s</last_analyze>\n", analyze))
+					}
+				}
+				for _, index := range stats.Indexes {
+					result.WriteString("      <index>\n")
+					result.WriteString(fmt.Sprintf("        <name>%s</name>\n", f.escapeXML(index.Name)))
+					result.WriteString(fmt.Sprintf("        <columns>%s</columns>\n", f.escapeXML(strings.Join(index.Columns, ", "))))
+					result.WriteString(fmt.Sprintf("        <unique>%t</unique>\n", index.Unique))
+					if info.DBType == "postgres" {
+						result.WriteString(fmt.Sprintf("        <scans>%d</scans>\n", index.Scans))
+						result.WriteString(fmt.Sprintf("        <size_bytes>%d</size_bytes>\n", index.SizeBytes))
+						result.WriteString(fmt.Sprintf("        <unused>%t</unused>\n", index.Unused))
+					} else {
+						result.WriteString(fmt.Sprintf("        <cardinality>%d</cardinality>\n", index.Cardinality))
+					}
+					if index.SizePages > 0 {
+						result.WriteString(fmt.Sprintf("        <size_pages>%d</size_pages>\n", index.SizePages))
+						result.WriteString(fmt.Sprintf("        <leaf_pages>%d</leaf_pages>\n", index.LeafPages))
+					}
+					result.WriteString("      </index>\n")
+				}
+				for _, histogram := range stats.Histograms {
+					result.WriteString("      <histogram>\n")
+					result.WriteString(fmt.Sprintf("        <column>%s</column>\n", f.escapeXML(histogram.Column)))
+					result.WriteString(fmt.Sprintf("        <type>%s</type>\n", f.escapeXML(histogram.Type)))
+					result.WriteString(fmt.Sprintf("        <buckets>%d</buckets>\n", histogram.Buckets))
+					result.WriteString(fmt.Sprintf("        <sampling_rate>%.2f</sampling_rate>\n", histogram.SamplingRate))
+					result.WriteString(fmt.Sprintf("        <last_updated>%s</last_updated>\n", f.escapeXML(histogram.LastUpdated)))
+					result.WriteString("      </histogram>\n")
+				}
+				result.WriteString("    </table>\n")
+			}
+		}
+		result.WriteString("  </table_statistics>\n")
+	}
+
 	// Views
-	views := f.filterTables(info.Tables, "VIEW")
+	views := f.filterTables(info.Tables, "VIEW", "MATERIALIZED VIEW")
 	if len(views) > 0 {
 		result.WriteString("  <views>\n")
 		for _, view := range views {
 			if view.DDL != "" {
 				result.WriteString("    <view>\n")
 				result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(view.Schema), f.escapeXML(view.Name)))
+				if view.Type == "MATERIALIZED VIEW" {
+					result.WriteString("      <materialized>true</materialized>\n")
+				}
+				if view.Comment != "" {
+					result.WriteString(fmt.Sprintf("      <comment>%s</comment>\n", f.escapeXML(view.Comment)))
+				}
 				result.WriteString("      <ddl><![CDATA[")
 				result.WriteString(view.DDL)
 				result.WriteString("]]></ddl>\n")
@@ -710,14 +1686,73 @@ func (f *XMLFormatter) Format(info *DatabaseInfo) (string, error) {
 		result.WriteString("  </views>\n")
 	}
 
+	// Sequences (PostgreSQL)
+	sequences := f.filterTables(info.Tables, "SEQUENCE")
+	if len(sequences) > 0 {
+		result.WriteString("  <sequences>\n")
+		for _, sequence := range sequences {
+			result.WriteString("    <sequence>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(sequence.Schema), f.escapeXML(sequence.Name)))
+			if sequence.DDL != "" {
+				result.WriteString("      <ddl><![CDATA[")
+				result.WriteString(sequence.DDL)
+				result.WriteString("]]></ddl>\n")
+			}
+			result.WriteString("    </sequence>\n")
+		}
+		result.WriteString("  </sequences>\n")
+	}
+
+	// User-defined types (PostgreSQL)
+	if len(info.Types) > 0 {
+		result.WriteString("  <types>\n")
+		for _, typeInfo := range info.Types {
+			result.WriteString("    <type>\n")
+			if typeInfo.Database != "" {
+				result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(typeInfo.Database)))
+			}
+			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(typeInfo.Schema), f.escapeXML(typeInfo.Name)))
+			result.WriteString(fmt.Sprintf("      <kind>%s</kind>\n", typeInfo.Kind))
+			if typeInfo.Owner != "" {
+				result.WriteString(fmt.Sprintf("      <owner>%s</owner>\n", f.escapeXML(typeInfo.Owner)))
+			}
+			if len(typeInfo.Labels) > 0 {
+				result.WriteString("      <labels>\n")
+				for _, label := range typeInfo.Labels {
+					result.WriteString(fmt.Sprintf("        <label>%s</label>\n", f.escapeXML(label)))
+				}
+				result.WriteString("      </labels>\n")
+			}
+			if typeInfo.BaseType != "" {
+				result.WriteString(fmt.Sprintf("      <base_type>%s</base_type>\n", f.escapeXML(typeInfo.BaseType)))
+			}
+			if typeInfo.Comment != "" {
+				result.WriteString(fmt.Sprintf("      <comment>%s</comment>\n", f.escapeXML(typeInfo.Comment)))
+			}
+			if typeInfo.DDL != "" {
+				result.WriteString("      <ddl><![CDATA[")
+				result.WriteString(typeInfo.DDL)
+				result.WriteString("]]></ddl>\n")
+			}
+			result.WriteString("    </type>\n")
+		}
+		result.WriteString("  </types>\n")
+	}
+
 	// Stored functions
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
 		result.WriteString("  <stored_functions>\n")
 		for _, function := range functions {
 			result.WriteString("    <function>\n")
+			if function.Database != "" {
+				result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(function.Database)))
+			}
 			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(function.Schema), f.escapeXML(function.Name)))
 			result.WriteString(fmt.Sprintf("      <security_type>%s</security_type>\n", f.escapeXML(function.SecurityType)))
+			if function.Comment != "" {
+				result.WriteString(fmt.Sprintf("      <comment>%s</comment>\n", f.escapeXML(function.Comment)))
+			}
 			if !function.Created.IsZero() {
 				result.WriteString(fmt.Sprintf("      <created>%s</created>\n", function.Created.Format("2006-01-02 15:04:05")))
 			}
@@ -737,8 +1772,14 @@ func (f *XMLFormatter) Format(info *DatabaseInfo) (string, error) {
 		result.WriteString("  <stored_procedures>\n")
 		for _, procedure := range procedures {
 			result.WriteString("    <procedure>\n")
+			if procedure.Database != "" {
+				result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(procedure.Database)))
+			}
 			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(procedure.Schema), f.escapeXML(procedure.Name)))
 			result.WriteString(fmt.Sprintf("      <security_type>%s</security_type>\n", f.escapeXML(procedure.SecurityType)))
+			if procedure.Comment != "" {
+				result.WriteString(fmt.Sprintf("      <comment>%s</comment>\n", f.escapeXML(procedure.Comment)))
+			}
 			if !procedure.Created.IsZero() {
 				result.WriteString(fmt.Sprintf("      <created>%s</created>\n", procedure.Created.Format("2006-01-02 15:04:05")))
 			}
@@ -749,78 +1790,493 @@ func (f *XMLFormatter) Format(info *DatabaseInfo) (string, error) {
 			}
 			result.WriteString("    </procedure>\n")
 		}
-		result.WriteString("  </stored_procedures>\n")
+		result.WriteString("  </stored_procedures>\n")
+	}
+
+	// Triggers
+	if len(info.Triggers) > 0 {
+		result.WriteString("  <triggers>\n")
+		for _, trigger := range info.Triggers {
+			result.WriteString("    <trigger>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(trigger.Schema), f.escapeXML(trigger.Name)))
+			result.WriteString(fmt.Sprintf("      <table>%s.%s</table>\n", f.escapeXML(trigger.Schema), f.escapeXML(trigger.Table)))
+			result.WriteString(fmt.Sprintf("      <timing>%s</timing>\n", f.escapeXML(trigger.Timing)))
+			result.WriteString(fmt.Sprintf("      <event>%s</event>\n", f.escapeXML(trigger.Event)))
+			result.WriteString(fmt.Sprintf("      <definer>%s</definer>\n", f.escapeXML(trigger.Definer)))
+			if trigger.SQLMode != "" {
+				result.WriteString(fmt.Sprintf("      <sql_mode>%s</sql_mode>\n", f.escapeXML(trigger.SQLMode)))
+			}
+			if !trigger.Created.IsZero() {
+				result.WriteString(fmt.Sprintf("      <created>%s</created>\n", trigger.Created.Format("2006-01-02 15:04:05")))
+			}
+			if trigger.Statement != "" {
+				result.WriteString("      <statement><![CDATA[")
+				result.WriteString(trigger.Statement)
+				result.WriteString("]]></statement>\n")
+			}
+			if trigger.DDL != "" {
+				result.WriteString("      <ddl><![CDATA[")
+				result.WriteString(trigger.DDL)
+				result.WriteString("]]></ddl>\n")
+			}
+			result.WriteString("    </trigger>\n")
+		}
+		result.WriteString("  </triggers>\n")
+	}
+
+	// Scheduled events
+	if len(info.Events) > 0 {
+		result.WriteString("  <events>\n")
+		for _, event := range info.Events {
+			result.WriteString("    <event>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s.%s</name>\n", f.escapeXML(event.Schema), f.escapeXML(event.Name)))
+			result.WriteString(fmt.Sprintf("      <type>%s</type>\n", f.escapeXML(event.Type)))
+			result.WriteString(fmt.Sprintf("      <schedule>%s</schedule>\n", f.escapeXML(event.Schedule)))
+			result.WriteString(fmt.Sprintf("      <status>%s</status>\n", f.escapeXML(event.Status)))
+			result.WriteString(fmt.Sprintf("      <on_completion>%s</on_completion>\n", f.escapeXML(event.OnCompletion)))
+			result.WriteString(fmt.Sprintf("      <definer>%s</definer>\n", f.escapeXML(event.Definer)))
+			if event.SQLMode != "" {
+				result.WriteString(fmt.Sprintf("      <sql_mode>%s</sql_mode>\n", f.escapeXML(event.SQLMode)))
+			}
+			if event.Comment != "" {
+				result.WriteString(fmt.Sprintf("      <comment>%s</comment>\n", f.escapeXML(event.Comment)))
+			}
+			if event.Body != "" {
+				result.WriteString("      <body><![CDATA[")
+				result.WriteString(event.Body)
+				result.WriteString("]]></body>\n")
+			}
+			if event.DDL != "" {
+				result.WriteString("      <ddl><![CDATA[")
+				result.WriteString(event.DDL)
+				result.WriteString("]]></ddl>\n")
+			}
+			result.WriteString("    </event>\n")
+		}
+		result.WriteString("  </events>\n")
+	}
+
+	// Event triggers (PostgreSQL)
+	if len(info.EventTriggers) > 0 {
+		result.WriteString("  <event_triggers>\n")
+		for _, trigger := range info.EventTriggers {
+			result.WriteString("    <event_trigger>\n")
+			if trigger.Database != "" {
+				result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(trigger.Database)))
+			}
+			result.WriteString(fmt.Sprintf("      <name>%s</name>\n", f.escapeXML(trigger.Name)))
+			result.WriteString(fmt.Sprintf("      <event>%s</event>\n", f.escapeXML(trigger.Event)))
+			if len(trigger.Tags) > 0 {
+				result.WriteString("      <tags>\n")
+				for _, tag := range trigger.Tags {
+					result.WriteString(fmt.Sprintf("        <tag>%s</tag>\n", f.escapeXML(tag)))
+				}
+				result.WriteString("      </tags>\n")
+			}
+			result.WriteString(fmt.Sprintf("      <function>%s</function>\n", f.escapeXML(trigger.Function)))
+			result.WriteString(fmt.Sprintf("      <owner>%s</owner>\n", f.escapeXML(trigger.Owner)))
+			result.WriteString(fmt.Sprintf("      <enabled>%s</enabled>\n", f.escapeXML(trigger.Enabled)))
+			if trigger.DDL != "" {
+				result.WriteString("      <ddl><![CDATA[")
+				result.WriteString(trigger.DDL)
+				result.WriteString("]]></ddl>\n")
+			}
+			result.WriteString("    </event_trigger>\n")
+		}
+		result.WriteString("  </event_triggers>\n")
+	}
+
+	// Roles (MySQL 8.0+)
+	if len(info.Roles) > 0 {
+		result.WriteString("  <user_roles>\n")
+		for _, role := range info.Roles {
+			result.WriteString("    <role>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s@%s</name>\n", f.escapeXML(role.RoleName), f.escapeXML(role.RoleHost)))
+			if len(role.Grants) > 0 {
+				result.WriteString("      <grants>\n")
+				for _, grant := range role.Grants {
+					result.WriteString(fmt.Sprintf("        <grant>%s</grant>\n", f.escapeXML(grant)))
+				}
+				result.WriteString("      </grants>\n")
+			}
+			result.WriteString("    </role>\n")
+		}
+		result.WriteString("  </user_roles>\n")
+	}
+
+	// Users
+	if len(info.Users) > 0 {
+		result.WriteString("  <users>\n")
+		for _, user := range info.Users {
+			result.WriteString("    <user>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s@%s</name>\n", f.escapeXML(user.User), f.escapeXML(user.Host)))
+			result.WriteString(fmt.Sprintf("      <plugin>%s</plugin>\n", f.escapeXML(user.Plugin)))
+			result.WriteString(fmt.Sprintf("      <account_locked>%s</account_locked>\n", user.AccountLocked))
+			if len(user.Grants) > 0 {
+				result.WriteString("      <grants>\n")
+				for _, grant := range user.Grants {
+					result.WriteString(fmt.Sprintf("        <grant>%s</grant>\n", f.escapeXML(grant)))
+				}
+				result.WriteString("      </grants>\n")
+			}
+			result.WriteString("    </user>\n")
+		}
+		result.WriteString("  </users>\n")
+	}
+
+	// Plugins
+	if len(info.Plugins) > 0 {
+		result.WriteString("  <plugins>\n")
+		for _, plugin := range info.Plugins {
+			result.WriteString("    <plugin>\n")
+			result.WriteString(fmt.Sprintf("      <name>%s</name>\n", f.escapeXML(plugin.Name)))
+			result.WriteString(fmt.Sprintf("      <status>%s</status>\n", f.escapeXML(plugin.Status)))
+			result.WriteString(fmt.Sprintf("      <type>%s</type>\n", f.escapeXML(plugin.Type)))
+			if plugin.Library != "" {
+				result.WriteString(fmt.Sprintf("      <library>%s</library>\n", f.escapeXML(plugin.Library)))
+			}
+			result.WriteString(fmt.Sprintf("      <version>%s</version>\n", f.escapeXML(plugin.Version)))
+			result.WriteString("    </plugin>\n")
+		}
+		result.WriteString("  </plugins>\n")
+	}
+
+	// Components (MySQL 8.0+)
+	if len(info.Components) > 0 {
+		result.WriteString("  <components>\n")
+		for _, component := range info.Components {
+			result.WriteString("    <component>\n")
+			result.WriteString(fmt.Sprintf("      <id>%d</id>\n", component.ComponentID))
+			result.WriteString(fmt.Sprintf("      <urn>%s</urn>\n", f.escapeXML(component.ComponentURN)))
+			result.WriteString("    </component>\n")
+		}
+		result.WriteString("  </components>\n")
+	}
+
+	// Replication information
+	if replication := info.ReplicationInfo; replication != nil {
+		result.WriteString("  <replication>\n")
+		if status := replication.ReplicationStatus; status != nil {
+			result.WriteString("    <replication_status>\n")
+			result.WriteString(fmt.Sprintf("      <server_id>%d</server_id>\n", status.ServerID))
+			result.WriteString(fmt.Sprintf("      <server_uuid>%s</server_uuid>\n", f.escapeXML(status.ServerUUID)))
+			result.WriteString(fmt.Sprintf("      <log_bin_enabled>%t</log_bin_enabled>\n", status.LogBinEnabled))
+			result.WriteString(fmt.Sprintf("      <binlog_format>%s</binlog_format>\n", f.escapeXML(status.BinlogFormat)))
+			if status.CurrentLogFile != "" {
+				result.WriteString(fmt.Sprintf("      <current_log_file>%s</current_log_file>\n", f.escapeXML(status.CurrentLogFile)))
+				result.WriteString(fmt.Sprintf("      <current_log_pos>%d</current_log_pos>\n", status.CurrentLogPos))
+			}
+			result.WriteString(fmt.Sprintf("      <gtid_mode>%s</gtid_mode>\n", f.escapeXML(status.GTIDMode)))
+			result.WriteString("    </replication_status>\n")
+		}
+		if replication.Role != "" {
+			result.WriteString(fmt.Sprintf("    <role>%s</role>\n", replication.Role))
+		}
+		if len(replication.Standbys) > 0 {
+			result.WriteString("    <standbys>\n")
+			for _, standby := range replication.Standbys {
+				result.WriteString("      <standby>\n")
+				result.WriteString(fmt.Sprintf("        <application_name>%s</application_name>\n", f.escapeXML(standby.ApplicationName)))
+				result.WriteString(fmt.Sprintf("        <client_addr>%s</client_addr>\n", f.escapeXML(standby.ClientAddr)))
+				result.WriteString(fmt.Sprintf("        <state>%s</state>\n", f.escapeXML(standby.State)))
+				result.WriteString(fmt.Sprintf("        <sync_state>%s</sync_state>\n", f.escapeXML(standby.SyncState)))
+				result.WriteString(fmt.Sprintf("        <sent_lsn>%s</sent_lsn>\n", standby.SentLSN))
+				result.WriteString(fmt.Sprintf("        <write_lsn>%s</write_lsn>\n", standby.WriteLSN))
+				result.WriteString(fmt.Sprintf("        <flush_lsn>%s</flush_lsn>\n", standby.FlushLSN))
+				result.WriteString(fmt.Sprintf("        <replay_lsn>%s</replay_lsn>\n", standby.ReplayLSN))
+				result.WriteString(fmt.Sprintf("        <write_lag>%s</write_lag>\n", standby.WriteLag))
+				result.WriteString(fmt.Sprintf("        <flush_lag>%s</flush_lag>\n", standby.FlushLag))
+				result.WriteString(fmt.Sprintf("        <replay_lag>%s</replay_lag>\n", standby.ReplayLag))
+				result.WriteString("      </standby>\n")
+			}
+			result.WriteString("    </standbys>\n")
+		}
+		if len(replication.ReplicationSlots) > 0 {
+			result.WriteString("    <replication_slots>\n")
+			for _, slot := range replication.ReplicationSlots {
+				result.WriteString("      <slot>\n")
+				result.WriteString(fmt.Sprintf("        <name>%s</name>\n", f.escapeXML(slot.Name)))
+				result.WriteString(fmt.Sprintf("        <type>%s</type>\n", slot.Type))
+				if slot.Plugin != "" {
+					result.WriteString(fmt.Sprintf("        <plugin>%s</plugin>\n", f.escapeXML(slot.Plugin)))
+				}
+				if slot.Database != "" {
+					result.WriteString(fmt.Sprintf("        <database>%s</database>\n", f.escapeXML(slot.Database)))
+				}
+				result.WriteString(fmt.Sprintf("        <active>%t</active>\n", slot.Active))
+				result.WriteString(fmt.Sprintf("        <wal_status>%s</wal_status>\n", slot.WALStatus))
+				result.WriteString(fmt.Sprintf("        <retained_wal>%s</retained_wal>\n", slot.RetainedWAL))
+				result.WriteString("      </slot>\n")
+			}
+			result.WriteString("    </replication_slots>\n")
+		}
+		if receiver := replication.WALReceiver; receiver != nil {
+			result.WriteString("    <wal_receiver>\n")
+			result.WriteString(fmt.Sprintf("      <status>%s</status>\n", f.escapeXML(receiver.Status)))
+			result.WriteString(fmt.Sprintf("      <sender_host>%s</sender_host>\n", f.escapeXML(receiver.SenderHost)))
+			result.WriteString(fmt.Sprintf("      <sender_port>%d</sender_port>\n", receiver.SenderPort))
+			if receiver.SlotName != "" {
+				result.WriteString(fmt.Sprintf("      <slot_name>%s</slot_name>\n", f.escapeXML(receiver.SlotName)))
+			}
+			result.WriteString(fmt.Sprintf("      <flushed_lsn>%s</flushed_lsn>\n", receiver.FlushedLSN))
+			result.WriteString(fmt.Sprintf("      <latest_end_lsn>%s</latest_end_lsn>\n", receiver.LatestEndLSN))
+			result.WriteString(fmt.Sprintf("      <last_msg_receipt_time>%s</last_msg_receipt_time>\n", receiver.LastMsgReceiptTime))
+			result.WriteString("    </wal_receiver>\n")
+		}
+		if len(replication.Publications) > 0 {
+			result.WriteString("    <publications>\n")
+			for _, pub := range replication.Publications {
+				result.WriteString("      <publication>\n")
+				if pub.Database != "" {
+					result.WriteString(fmt.Sprintf("        <database>%s</database>\n", f.escapeXML(pub.Database)))
+				}
+				result.WriteString(fmt.Sprintf("        <name>%s</name>\n", f.escapeXML(pub.Name)))
+				result.WriteString(fmt.Sprintf("        <owner>%s</owner>\n", f.escapeXML(pub.Owner)))
+				result.WriteString(fmt.Sprintf("        <all_tables>%t</all_tables>\n", pub.AllTables))
+				for _, table := range pub.Tables {
+					result.WriteString(fmt.Sprintf("        <table>%s</table>\n", f.escapeXML(table)))
+				}
+				result.WriteString("        <ddl><![CDATA[")
+				result.WriteString(pub.DDL)
+				result.WriteString("]]></ddl>\n")
+				result.WriteString("      </publication>\n")
+			}
+			result.WriteString("    </publications>\n")
+		}
+		if len(replication.Subscriptions) > 0 {
+			result.WriteString("    <subscriptions>\n")
+			for _, sub := range replication.Subscriptions {
+				result.WriteString("      <subscription>\n")
+				if sub.Database != "" {
+					result.WriteString(fmt.Sprintf("        <database>%s</database>\n", f.escapeXML(sub.Database)))
+				}
+				result.WriteString(fmt.Sprintf("        <name>%s</name>\n", f.escapeXML(sub.Name)))
+				result.WriteString(fmt.Sprintf("        <owner>%s</owner>\n", f.escapeXML(sub.Owner)))
+				result.WriteString(fmt.Sprintf("        <enabled>%t</enabled>\n", sub.Enabled))
+				for _, pub := range sub.Publications {
+					result.WriteString(fmt.Sprintf("        <publication>%s</publication>\n", f.escapeXML(pub)))
+				}
+				result.WriteString("        <ddl><![CDATA[")
+				result.WriteString(sub.DDL)
+				result.WriteString("]]></ddl>\n")
+				result.WriteString("      </subscription>\n")
+			}
+			result.WriteString("    </subscriptions>\n")
+		}
+		result.WriteString("  </replication>\n")
+	}
+
+	// Workload
+	if len(info.Workload) > 0 {
+		result.WriteString("  <workload>\n")
+		for _, digest := range info.Workload {
+			result.WriteString("    <statement>\n")
+			result.WriteString(fmt.Sprintf("      <digest>%s</digest>\n", f.escapeXML(digest.Digest)))
+			if digest.Database != "" {
+				result.WriteString(fmt.Sprintf("      <database>%s</database>\n", f.escapeXML(digest.Database)))
+			}
+			if digest.User != "" {
+				result.WriteString(fmt.Sprintf("      <user>%s</user>\n", f.escapeXML(digest.User)))
+			}
+			result.WriteString(fmt.Sprintf("      <calls>%d</calls>\n", digest.Calls))
+			result.WriteString(fmt.Sprintf("      <total_time_ms>%.3f</total_time_ms>\n", digest.TotalTimeMs))
+			result.WriteString(fmt.Sprintf("      <mean_time_ms>%.3f</mean_time_ms>\n", digest.MeanTimeMs))
+			if digest.RowsExamined > 0 {
+				result.WriteString(fmt.Sprintf("      <rows_examined>%d</rows_examined>\n", digest.RowsExamined))
+			}
+			result.WriteString(fmt.Sprintf("      <rows_returned>%d</rows_returned>\n", digest.RowsReturned))
+			if digest.BlocksHit > 0 || digest.BlocksRead > 0 {
+				result.WriteString(fmt.Sprintf("      <blocks_hit>%d</blocks_hit>\n", digest.BlocksHit))
+				result.WriteString(fmt.Sprintf("      <blocks_read>%d</blocks_read>\n", digest.BlocksRead))
+			}
+			result.WriteString("      <query><![CDATA[")
+			result.WriteString(digest.Query)
+			result.WriteString("]]></query>\n")
+			result.WriteString("    </statement>\n")
+		}
+		result.WriteString("  </workload>\n")
+	}
+
+	// Lint findings
+	if info.Lint != nil {
+		result.WriteString(fmt.Sprintf("  <lint summary=\"%s\">\n", findingsSummary(info.Lint)))
+		f.formatFindings(&result, info.Lint)
+		result.WriteString("  </lint>\n")
+	}
+
+	// Security audit findings
+	if info.Security != nil {
+		result.WriteString(fmt.Sprintf("  <security summary=\"%s\">\n", findingsSummary(info.Security)))
+		f.formatFindings(&result, info.Security)
+		result.WriteString("  </security>\n")
+	}
+
+	// Effective privileges
+	if info.Access != nil {
+		result.WriteString("  <effective_privileges>\n")
+		for _, user := range info.Access.Users {
+			result.WriteString(fmt.Sprintf("    <user name=\"%s\" superuser=\"%t\">\n", f.escapeXML(user.User), user.Superuser))
+			for _, role := range user.Roles {
+				result.WriteString(fmt.Sprintf("      <role path=\"%s\" set_role=\"%t\">%s</role>\n",
+					f.escapeXML(strings.Join(role.Path, " -> ")), role.SetRole, f.escapeXML(role.Role)))
+			}
+			for _, group := range groupEffectivePrivileges(user.Privileges) {
+				result.WriteString(fmt.Sprintf("      <privilege object=\"%s\" via=\"%s\">%s</privilege>\n",
+					f.escapeXML(group.Object), f.escapeXML(group.Source), f.escapeXML(strings.Join(group.Privileges, ", "))))
+			}
+			result.WriteString("    </user>\n")
+		}
+		if len(info.Access.Writers) > 0 {
+			result.WriteString("    <table_writers>\n")
+			for _, table := range info.Access.Writers {
+				result.WriteString(fmt.Sprintf("      <table name=\"%s\">\n", f.escapeXML(table.Table)))
+				for _, writer := range table.Writers {
+					result.WriteString(fmt.Sprintf("        <writer user=\"%s\" via=\"%s\">%s</writer>\n",
+						f.escapeXML(writer.User), f.escapeXML(accessSource(writer.Via, writer.SetRole)),
+						f.escapeXML(strings.Join(writer.Privileges, ", "))))
+				}
+				result.WriteString("      </table>\n")
+			}
+			result.WriteString("    </table_writers>\n")
+		}
+		result.WriteString("  </effective_privileges>\n")
+	}
+
+	// Upgrade check
+	if info.Upgrade != nil {
+		result.WriteString(fmt.Sprintf("  <upgrade_check source=\"%s\" target=\"%s\" summary=\"%s\">\n",
+			f.escapeXML(info.Upgrade.Source), f.escapeXML(info.Upgrade.Target), findingsSummary(info.Upgrade.Findings)))
+		f.formatFindings(&result, info.Upgrade.Findings)
+		result.WriteString("  </upgrade_check>\n")
+	}
+
+	// Migration check
+	if info.Migration != nil {
+		result.WriteString(fmt.Sprintf("  <migration_check source=\"%s\" target=\"%s\" summary=\"%s\">\n",
+			f.escapeXML(info.Migration.Source), f.escapeXML(info.Migration.Target), findingsSummary(info.Migration.Findings)))
+		result.WriteString("    <objects>\n")
+		for _, object := range info.Migration.Objects {
+			result.WriteString(fmt.Sprintf("      <object status=\"%s\">%s</object>\n", object.Status, f.escapeXML(object.Object)))
+		}
+		result.WriteString("    </objects>\n")
+		result.WriteString("    <type_mappings>\n")
+		for _, mapping := range info.Migration.TypeMappings {
+			result.WriteString(fmt.Sprintf("      <type_mapping from=\"%s\" to=\"%s\" columns=\"%d\"/>\n",
+				f.escapeXML(mapping.From), f.escapeXML(mapping.To), mapping.Columns))
+		}
+		result.WriteString("    </type_mappings>\n")
+		f.formatFindings(&result, info.Migration.Findings)
+		result.WriteString("  </migration_check>\n")
 	}
 
-	// Roles (MySQL 8.0+)
-	if len(info.Roles) > 0 {
-		result.WriteString("  <user_roles>\n")
-		for _, role := range info.Roles {
-			result.WriteString("    <role>\n")
-			result.WriteString(fmt.Sprintf("      <name>%s@%s</name>\n", f.escapeXML(role.RoleName), f.escapeXML(role.RoleHost)))
-			if len(role.Grants) > 0 {
-				result.WriteString("      <grants>\n")
-				for _, grant := range role.Grants {
-					result.WriteString(fmt.Sprintf("        <grant>%s</grant>\n", f.escapeXML(grant)))
-				}
-				result.WriteString("      </grants>\n")
+	result.WriteString("</database_info>\n")
+	return result.String(), nil
+}
+
+// formatTableStructure writes the columns, indexes, foreign keys and check constraints of a table
+func (f *XMLFormatter) formatTableStructure(result *strings.Builder, table TableInfo) {
+	if len(table.Columns) > 0 {
+		result.WriteString("      <columns>\n")
+		for _, column := range table.Columns {
+			result.WriteString("        <column>\n")
+			result.WriteString(fmt.Sprintf("          <name>%s</name>\n", f.escapeXML(column.Name)))
+			result.WriteString(fmt.Sprintf("          <position>%d</position>\n", column.Position))
+			result.WriteString(fmt.Sprintf("          <type>%s</type>\n", f.escapeXML(column.Type)))
+			result.WriteString(fmt.Sprintf("          <nullable>%t</nullable>\n", column.Nullable))
+			if column.Default != "" {
+				result.WriteString(fmt.Sprintf("          <default>%s</default>\n", f.escapeXML(column.Default)))
 			}
-			result.WriteString("    </role>\n")
+			if column.Charset != "" {
+				result.WriteString(fmt.Sprintf("          <charset>%s</charset>\n", f.escapeXML(column.Charset)))
+			}
+			if column.Collation != "" {
+				result.WriteString(fmt.Sprintf("          <collation>%s</collation>\n", f.escapeXML(column.Collation)))
+			}
+			if column.AutoIncrement {
+				result.WriteString("          <auto_increment>true</auto_increment>\n")
+			}
+			if column.OnUpdate != "" {
+				result.WriteString(fmt.Sprintf("          <on_update>%s</on_update>\n", f.escapeXML(column.OnUpdate)))
+			}
+			if column.Identity != "" {
+				result.WriteString(fmt.Sprintf("          <identity>%s</identity>\n", column.Identity))
+			}
+			if column.Generated != "" {
+				result.WriteString(fmt.Sprintf("          <generated>%s</generated>\n", f.escapeXML(column.Generated)))
+			}
+			if column.Comment != "" {
+				result.WriteString(fmt.Sprintf("          <comment>%s</comment>\n", f.escapeXML(column.Comment)))
+			}
+			result.WriteString("        </column>\n")
 		}
-		result.WriteString("  </user_roles>\n")
+		result.WriteString("      </columns>\n")
 	}
 
-	// Users
-	if len(info.Users) > 0 {
-		result.WriteString("  <users>\n")
-		for _, user := range info.Users {
-			result.WriteString("    <user>\n")
-			result.WriteString(fmt.Sprintf("      <name>%s@%s</name>\n", f.escapeXML(user.User), f.escapeXML(user.Host)))
-			result.WriteString(fmt.Sprintf("      <plugin>%s</plugin>\n", f.escapeXML(user.Plugin)))
-			result.WriteString(fmt.Sprintf("      <account_locked>%s</account_locked>\n", user.AccountLocked))
-			if len(user.Grants) > 0 {
-				result.WriteString("      <grants>\n")
-				for _, grant := range user.Grants {
-					result.WriteString(fmt.Sprintf("        <grant>%s</grant>\n", f.escapeXML(grant)))
-				}
-				result.WriteString("      </grants>\n")
+	if len(table.Indexes) > 0 {
+		result.WriteString("      <indexes>\n")
+		for _, index := range table.Indexes {
+			result.WriteString("        <index>\n")
+			result.WriteString(fmt.Sprintf("          <name>%s</name>\n", f.escapeXML(index.Name)))
+			for _, column := range index.Columns {
+				result.WriteString(fmt.Sprintf("          <column>%s</column>\n", f.escapeXML(column)))
 			}
-			result.WriteString("    </user>\n")
+			result.WriteString(fmt.Sprintf("          <primary>%t</primary>\n", index.Primary))
+			result.WriteString(fmt.Sprintf("          <unique>%t</unique>\n", index.Unique))
+			if index.Method != "" {
+				result.WriteString(fmt.Sprintf("          <method>%s</method>\n", f.escapeXML(index.Method)))
+			}
+			if index.Predicate != "" {
+				result.WriteString(fmt.Sprintf("          <predicate>%s</predicate>\n", f.escapeXML(index.Predicate)))
+			}
+			result.WriteString("        </index>\n")
 		}
-		result.WriteString("  </users>\n")
+		result.WriteString("      </indexes>\n")
 	}
 
-	// Plugins
-	if len(info.Plugins) > 0 {
-		result.WriteString("  <plugins>\n")
-		for _, plugin := range info.Plugins {
-			result.WriteString("    <plugin>\n")
-			result.WriteString(fmt.Sprintf("      <name>%s</name>\n", f.escapeXML(plugin.Name)))
-			result.WriteString(fmt.Sprintf("      <status>%s</status>\n", f.escapeXML(plugin.Status)))
-			result.WriteString(fmt.Sprintf("      <type>%s</type>\n", f.escapeXML(plugin.Type)))
-			if plugin.Library != "" {
-				result.WriteString(fmt.Sprintf("      <library>%s</library>\n", f.escapeXML(plugin.Library)))
+	if len(table.ForeignKeys) > 0 {
+		result.WriteString("      <foreign_keys>\n")
+		for _, fk := range table.ForeignKeys {
+			result.WriteString("        <foreign_key>\n")
+			result.WriteString(fmt.Sprintf("          <name>%s</name>\n", f.escapeXML(fk.Name)))
+			for _, column := range fk.Columns {
+				result.WriteString(fmt.Sprintf("          <column>%s</column>\n", f.escapeXML(column)))
 			}
-			result.WriteString(fmt.Sprintf("      <version>%s</version>\n", f.escapeXML(plugin.Version)))
-			result.WriteString("    </plugin>\n")
+			result.WriteString(fmt.Sprintf("          <ref_table>%s.%s</ref_table>\n", f.escapeXML(fk.RefSchema), f.escapeXML(fk.RefTable)))
+			for _, column := range fk.RefColumns {
+				result.WriteString(fmt.Sprintf("          <ref_column>%s</ref_column>\n", f.escapeXML(column)))
+			}
+			result.WriteString(fmt.Sprintf("          <on_update>%s</on_update>\n", fk.OnUpdate))
+			result.WriteString(fmt.Sprintf("          <on_delete>%s</on_delete>\n", fk.OnDelete))
+			result.WriteString("        </foreign_key>\n")
 		}
-		result.WriteString("  </plugins>\n")
+		result.WriteString("      </foreign_keys>\n")
 	}
 
-	// Components (MySQL 8.0+)
-	if len(info.Components) > 0 {
-		result.WriteString("  <components>\n")
-		for _, component := range info.Components {
-			result.WriteString("    <component>\n")
-			result.WriteString(fmt.Sprintf("      <id>%d</id>\n", component.ComponentID))
-			result.WriteString(fmt.Sprintf("      <urn>%s</urn>\n", f.escapeXML(component.ComponentURN)))
-			result.WriteString("    </component>\n")
+	if len(table.CheckConstraints) > 0 {
+		result.WriteString("      <check_constraints>\n")
+		for _, check := range table.CheckConstraints {
+			result.WriteString("        <check_constraint>\n")
+			result.WriteString(fmt.Sprintf("          <name>%s</name>\n", f.escapeXML(check.Name)))
+			result.WriteString(fmt.Sprintf("          <expression>%s</expression>\n", f.escapeXML(check.Expression)))
+			if check.NotEnforced {
+				result.WriteString("          <enforced>false</enforced>\n")
+			}
+			result.WriteString("        </check_constraint>\n")
 		}
-		result.WriteString("  </components>\n")
+		result.WriteString("      </check_constraints>\n")
 	}
+}
 
-	result.WriteString("</database_info>\n")
-	return result.String(), nil
+// formatFindings writes findings as <finding> elements of the enclosing section
+func (f *XMLFormatter) formatFindings(result *strings.Builder, findings []Finding) {
+	for _, finding := range findings {
+		result.WriteString("    <finding>\n")
+		result.WriteString(fmt.Sprintf("      <severity>%s</severity>\n", finding.Severity))
+		result.WriteString(fmt.Sprintf("      <rule>%s</rule>\n", finding.Rule))
+		result.WriteString(fmt.Sprintf("      <object>%s</object>\n", f.escapeXML(finding.Object)))
+		result.WriteString(fmt.Sprintf("      <message>%s</message>\n", f.escapeXML(finding.Message)))
+		result.WriteString("    </finding>\n")
+	}
 }
 
 func (f *XMLFormatter) escapeXML(s string) string {
@@ -845,9 +2301,18 @@ func (f *XMLFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(info.Tables) > 0 {
 		sections = append(sections, "Tables - Database tables with metadata and DDL definitions")
 	}
+	if hasTableStats(info.Tables) {
+		sections = append(sections, "Table Statistics - Row estimates, sizes, index usage and statistics")
+	}
 	if f.hasViews(info.Tables) {
 		sections = append(sections, "View Details - Database views with their definitions")
 	}
+	if len(f.filterTables(info.Tables, "SEQUENCE")) > 0 {
+		sections = append(sections, "Sequences - PostgreSQL sequences with their current values")
+	}
+	if len(info.Types) > 0 {
+		sections = append(sections, "Types - User-defined types, domains and collations")
+	}
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
 		sections = append(sections, "Stored Functions - User-defined functions with their definitions")
@@ -856,6 +2321,15 @@ func (f *XMLFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(procedures) > 0 {
 		sections = append(sections, "Stored Procedures - User-defined procedures with their definitions")
 	}
+	if len(info.Triggers) > 0 {
+		sections = append(sections, "Triggers - Table triggers with their definitions")
+	}
+	if len(info.Events) > 0 {
+		sections = append(sections, "Events - Scheduled events with their schedules and definitions")
+	}
+	if len(info.EventTriggers) > 0 {
+		sections = append(sections, "Event Triggers - Database-wide DDL event triggers")
+	}
 	if len(info.Roles) > 0 {
 		sections = append(sections, "User Roles - Role definitions and assignments")
 	}
@@ -874,12 +2348,30 @@ func (f *XMLFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if info.ReplicationInfo != nil {
 		sections = append(sections, "Replication Info - Replication configuration and status")
 	}
+	if len(info.Workload) > 0 {
+		sections = append(sections, "Workload - Top statements by total execution time")
+	}
+	if info.Lint != nil {
+		sections = append(sections, "Lint - Schema lint findings by severity")
+	}
+	if info.Security != nil {
+		sections = append(sections, "Security Audit - Account and privilege findings by severity")
+	}
+	if info.Access != nil {
+		sections = append(sections, "Effective Privileges - Privileges of each user through role grants, and table writers")
+	}
+	if info.Upgrade != nil {
+		sections = append(sections, "Upgrade Check - Blockers and warnings for the target version")
+	}
+	if info.Migration != nil {
+		sections = append(sections, "Migration Check - How tables, views and stored programs translate to the target database")
+	}
 	return sections
 }
 
 func (f *XMLFormatter) hasViews(tables []TableInfo) bool {
 	for _, table := range tables {
-		if table.Type == "VIEW" {
+		if table.Type == "VIEW" || table.Type == "MATERIALIZED VIEW" {
 			return true
 		}
 	}
@@ -896,11 +2388,14 @@ func (f *XMLFormatter) filterRoutines(routines []RoutineInfo, routineType string
 	return filtered
 }
 
-func (f *XMLFormatter) filterTables(tables []TableInfo, tableType string) []TableInfo {
+func (f *XMLFormatter) filterTables(tables []TableInfo, tableTypes ...string) []TableInfo {
 	var filtered []TableInfo
 	for _, table := range tables {
-		if table.Type == tableType {
-			filtered = append(filtered, table)
+		for _, tableType := range tableTypes {
+			if table.Type == tableType {
+				filtered = append(filtered, table)
+				break
+			}
 		}
 	}
 	return filtered
@@ -1087,9 +2582,10 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 		result.WriteString("\n")
 	}
 
-	// Tables (BASE TABLE only)
-	baseTables := f.filterTables(info.Tables, "BASE TABLE")
+	// Tables (base and foreign tables)
+	baseTables := f.filterTables(info.Tables, "BASE TABLE", "FOREIGN TABLE")
 	if len(baseTables) > 0 {
+		partitions := partitionsByParent(info.Tables)
 		result.WriteString("Tables\n")
 		result.WriteString("======\n\n")
 		
@@ -1121,41 +2617,120 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 				} else {
 					result.WriteString(fmt.Sprintf("%s.%s\n", table.Schema, table.Name))
 				}
+				if table.Type == "FOREIGN TABLE" {
+					result.WriteString("  Type: FOREIGN TABLE\n")
+				}
 				if table.Engine != "" {
-				result.WriteString(fmt.Sprintf("  Engine: %s\n", table.Engine))
-			}
-			if table.AutoIncrement > 0 {
-				result.WriteString(fmt.Sprintf("  Auto Increment: %d\n", table.AutoIncrement))
-			}
-			if !table.CreatedAt.IsZero() {
-				result.WriteString(fmt.Sprintf("  Created: %s\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
-			}
-			if table.Collation != "" {
-				result.WriteString(fmt.Sprintf("  Collation: %s\n", table.Collation))
-			}
-			if table.Charset != "" {
-				result.WriteString(fmt.Sprintf("  Charset: %s\n", table.Charset))
-			}
-			if table.RowFormat != "" {
-				result.WriteString(fmt.Sprintf("  Row Format: %s\n", table.RowFormat))
-			}
-			if table.DDL != "" {
-				result.WriteString("  DDL:\n")
-				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(table.DDL, "\n", "\n    ")))
+					result.WriteString(fmt.Sprintf("  Engine: %s\n", table.Engine))
+				}
+				if table.PartitionKey != "" {
+					result.WriteString(fmt.Sprintf("  Partition Key: %s\n", table.PartitionKey))
+				}
+				if children := partitions[table.Schema+"."+table.Name]; len(children) > 0 {
+					result.WriteString(fmt.Sprintf("  Partitions: %s\n", strings.Join(children, ", ")))
+				}
+				if table.PartitionOf != "" {
+					result.WriteString(fmt.Sprintf("  Partition Of: %s\n", table.PartitionOf))
+					result.WriteString(fmt.Sprintf("  Partition Bound: %s\n", table.PartitionBound))
+				}
+				if label := rowSecurityLabel(table); label != "" {
+					result.WriteString(fmt.Sprintf("  Row Level Security: %s\n", label))
+				}
+				for _, policy := range table.Policies {
+					result.WriteString(fmt.Sprintf("  Policy: %s\n", policySummary(policy)))
+				}
+				if table.AutoIncrement > 0 {
+					result.WriteString(fmt.Sprintf("  Auto Increment: %d\n", table.AutoIncrement))
+				}
+				if !table.CreatedAt.IsZero() {
+					result.WriteString(fmt.Sprintf("  Created: %s\n", table.CreatedAt.Format("2006-01-02 15:04:05")))
+				}
+				if table.Collation != "" {
+					result.WriteString(fmt.Sprintf("  Collation: %s\n", table.Collation))
+				}
+				if table.Charset != "" {
+					result.WriteString(fmt.Sprintf("  Charset: %s\n", table.Charset))
+				}
+				if table.RowFormat != "" {
+					result.WriteString(fmt.Sprintf("  Row Format: %s\n", table.RowFormat))
+				}
+				if table.DDL != "" {
+					result.WriteString("  DDL:\n")
+					result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(table.DDL, "\n", "\n    ")))
+				}
+				result.WriteString("\n")
 			}
-			result.WriteString("\n")
+		}
+	}
+
+	// Table statistics
+	if hasTableStats(info.Tables) {
+		result.WriteString("Table Statistics\n")
+		result.WriteString("================\n\n")
+		labels, groups := tableStatsGroups(info.Tables)
+		for _, label := range labels {
+			for _, table := range groups[label] {
+				stats := table.Stats
+				result.WriteString(fmt.Sprintf("%s.%s\n", label, table.Name))
+				result.WriteString(fmt.Sprintf("  Rows (est.): %d\n", stats.RowEstimate))
+				if info.DBType == "postgres" {
+					result.WriteString(fmt.Sprintf("  Live Tuples: %d, Dead Tuples: %d\n", stats.LiveTuples, stats.DeadTuples))
+					result.WriteString(fmt.Sprintf("  Seq Scans: %d, Index Scans: %d\n", stats.SeqScans, stats.IndexScans))
+					result.WriteString(fmt.Sprintf("  Table: %s, TOAST: %s, Indexes: %s, Total: %s\n", formatBytes(stats.DataLength),
+						formatBytes(stats.ToastLength), formatBytes(stats.IndexLength), formatBytes(stats.TotalLength)))
+					if vacuum := lastMaintenance(stats.LastVacuum, stats.LastAutovacuum); vacuum != "" {
+						result.WriteString(fmt.Sprintf("  Last Vacuum: %s\n", vacuum))
+					}
+					if analyze := lastMaintenance(stats.LastAnalyze, stats.LastAutoanalyze); analyze != "" {
+						result.WriteString(fmt.Sprintf("  Last Analyze: %s\n", analyze))
+					}
+				} else {
+					result.WriteString(fmt.Sprintf("  Data: %s, Indexes: %s, Free: %s\n",
+						formatBytes(stats.DataLength), formatBytes(stats.IndexLength), formatBytes(stats.DataFree)))
+				}
+				for _, index := range stats.Indexes {
+					unique := ""
+					if index.Unique {
+						unique = " UNIQUE"
+					}
+					var line string
+					if info.DBType == "postgres" {
+						line = fmt.Sprintf("  Index: %s (%s)%s, %d scans, %s", index.Name, strings.Join(index.Columns, ", "), unique,
+							index.Scans, formatBytes(index.SizeBytes))
+						if index.Unused {
+							line += ", UNUSED"
+						}
+					} else {
+						line = fmt.Sprintf("  Index: %s (%s)%s, cardinality %d", index.Name, strings.Join(index.Columns, ", "), unique, index.Cardinality)
+					}
+					if index.SizePages > 0 {
+						line += fmt.Sprintf(", %d pages (%d leaf)", index.SizePages, index.LeafPages)
+					}
+					result.WriteString(line + "\n")
+				}
+				for _, histogram := range stats.Histograms {
+					result.WriteString(fmt.Sprintf("  Histogram: %s (%s, %d buckets, sampling rate %.2f, updated %s)\n",
+						histogram.Column, histogram.Type, histogram.Buckets, histogram.SamplingRate, histogram.LastUpdated))
+				}
+				result.WriteString("\n")
 			}
 		}
 	}
 
 	// Views
-	views := f.filterTables(info.Tables, "VIEW")
+	views := f.filterTables(info.Tables, "VIEW", "MATERIALIZED VIEW")
 	if len(views) > 0 {
 		result.WriteString("View Details\n")
 		result.WriteString("============\n\n")
 		for _, view := range views {
 			if view.DDL != "" {
 				result.WriteString(fmt.Sprintf("%s.%s\n", view.Schema, view.Name))
+				if view.Type == "MATERIALIZED VIEW" {
+					result.WriteString("  Type: MATERIALIZED VIEW\n")
+				}
+				if view.Comment != "" {
+					result.WriteString(fmt.Sprintf("  Comment: %s\n", view.Comment))
+				}
 				result.WriteString("  DDL:\n")
 				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(view.DDL, "\n", "\n    ")))
 				result.WriteString("\n")
@@ -1163,13 +2738,55 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 		}
 	}
 
+	// Sequences (PostgreSQL)
+	sequences := f.filterTables(info.Tables, "SEQUENCE")
+	if len(sequences) > 0 {
+		result.WriteString("Sequences\n")
+		result.WriteString("=========\n\n")
+		for _, sequence := range sequences {
+			result.WriteString(fmt.Sprintf("%s.%s\n", sequence.Schema, sequence.Name))
+			if sequence.DDL != "" {
+				result.WriteString("  DDL:\n")
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(sequence.DDL, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// User-defined types (PostgreSQL)
+	if len(info.Types) > 0 {
+		result.WriteString("Types\n")
+		result.WriteString("=====\n\n")
+		for _, typeInfo := range info.Types {
+			result.WriteString(fmt.Sprintf("%s\n", qualifiedName(typeInfo.Database, typeInfo.Schema, typeInfo.Name)))
+			result.WriteString(fmt.Sprintf("  Kind: %s\n", typeInfo.Kind))
+			if typeInfo.Owner != "" {
+				result.WriteString(fmt.Sprintf("  Owner: %s\n", typeInfo.Owner))
+			}
+			if len(typeInfo.Labels) > 0 {
+				result.WriteString(fmt.Sprintf("  Labels: %s\n", strings.Join(typeInfo.Labels, ", ")))
+			}
+			if typeInfo.BaseType != "" {
+				result.WriteString(fmt.Sprintf("  Base Type: %s\n", typeInfo.BaseType))
+			}
+			if typeInfo.Comment != "" {
+				result.WriteString(fmt.Sprintf("  Comment: %s\n", typeInfo.Comment))
+			}
+			if typeInfo.DDL != "" {
+				result.WriteString("  DDL:\n")
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(typeInfo.DDL, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
 	// Stored functions
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
 		result.WriteString("Stored Functions\n")
 		result.WriteString("================\n\n")
 		for _, function := range functions {
-			result.WriteString(fmt.Sprintf("%s.%s\n", function.Schema, function.Name))
+			result.WriteString(fmt.Sprintf("%s\n", qualifiedName(function.Database, function.Schema, function.Name)))
 			result.WriteString(fmt.Sprintf("  Specific Name: %s\n", function.Name))
 			result.WriteString("  Routine Catalog: def\n")
 			result.WriteString("  Routine Body: SQL\n")
@@ -1180,6 +2797,9 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 			if function.SecurityType != "" {
 				result.WriteString(fmt.Sprintf("  Security Type: %s\n", function.SecurityType))
 			}
+			if function.Comment != "" {
+				result.WriteString(fmt.Sprintf("  Comment: %s\n", function.Comment))
+			}
 			if !function.Created.IsZero() {
 				result.WriteString(fmt.Sprintf("  Created: %s\n", function.Created.Format("2006-01-02 15:04:05")))
 			}
@@ -1197,7 +2817,7 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 		result.WriteString("Stored Procedures\n")
 		result.WriteString("=================\n\n")
 		for _, procedure := range procedures {
-			result.WriteString(fmt.Sprintf("%s.%s\n", procedure.Schema, procedure.Name))
+			result.WriteString(fmt.Sprintf("%s\n", qualifiedName(procedure.Database, procedure.Schema, procedure.Name)))
 			result.WriteString(fmt.Sprintf("  Specific Name: %s\n", procedure.Name))
 			result.WriteString("  Routine Catalog: def\n")
 			result.WriteString("  Routine Body: SQL\n")
@@ -1208,6 +2828,9 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 			if procedure.SecurityType != "" {
 				result.WriteString(fmt.Sprintf("  Security Type: %s\n", procedure.SecurityType))
 			}
+			if procedure.Comment != "" {
+				result.WriteString(fmt.Sprintf("  Comment: %s\n", procedure.Comment))
+			}
 			if !procedure.Created.IsZero() {
 				result.WriteString(fmt.Sprintf("  Created: %s\n", procedure.Created.Format("2006-01-02 15:04:05")))
 			}
@@ -1219,6 +2842,95 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 		}
 	}
 
+	// Triggers
+	if len(info.Triggers) > 0 {
+		result.WriteString("Triggers\n")
+		result.WriteString("========\n\n")
+		for _, trigger := range info.Triggers {
+			result.WriteString(fmt.Sprintf("%s.%s\n", trigger.Schema, trigger.Name))
+			result.WriteString(fmt.Sprintf("  Table: %s.%s\n", trigger.Schema, trigger.Table))
+			result.WriteString(fmt.Sprintf("  Timing: %s %s\n", trigger.Timing, trigger.Event))
+			if trigger.Definer != "" {
+				result.WriteString(fmt.Sprintf("  Definer: %s\n", trigger.Definer))
+			}
+			if trigger.SQLMode != "" {
+				result.WriteString(fmt.Sprintf("  SQL Mode: %s\n", trigger.SQLMode))
+			}
+			if !trigger.Created.IsZero() {
+				result.WriteString(fmt.Sprintf("  Created: %s\n", trigger.Created.Format("2006-01-02 15:04:05")))
+			}
+			ddl := trigger.DDL
+			if ddl == "" {
+				ddl = trigger.Statement
+			}
+			if ddl != "" {
+				result.WriteString("  DDL:\n")
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(ddl, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// Scheduled events
+	if len(info.Events) > 0 {
+		result.WriteString("Events\n")
+		result.WriteString("======\n\n")
+		for _, event := range info.Events {
+			result.WriteString(fmt.Sprintf("%s.%s\n", event.Schema, event.Name))
+			result.WriteString(fmt.Sprintf("  Type: %s\n", event.Type))
+			if event.Schedule != "" {
+				result.WriteString(fmt.Sprintf("  Schedule: %s\n", event.Schedule))
+			}
+			result.WriteString(fmt.Sprintf("  Status: %s\n", event.Status))
+			if event.OnCompletion != "" {
+				result.WriteString(fmt.Sprintf("  On Completion: %s\n", event.OnCompletion))
+			}
+			if event.Definer != "" {
+				result.WriteString(fmt.Sprintf("  Definer: %s\n", event.Definer))
+			}
+			if event.SQLMode != "" {
+				result.WriteString(fmt.Sprintf("  SQL Mode: %s\n", event.SQLMode))
+			}
+			if event.Comment != "" {
+				result.WriteString(fmt.Sprintf("  Comment: %s\n", event.Comment))
+			}
+			ddl := event.DDL
+			if ddl == "" {
+				ddl = event.Body
+			}
+			if ddl != "" {
+				result.WriteString("  DDL:\n")
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(ddl, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// Event triggers (PostgreSQL)
+	if len(info.EventTriggers) > 0 {
+		result.WriteString("Event Triggers\n")
+		result.WriteString("==============\n\n")
+		for _, trigger := range info.EventTriggers {
+			if trigger.Database != "" {
+				result.WriteString(fmt.Sprintf("%s.%s\n", trigger.Database, trigger.Name))
+			} else {
+				result.WriteString(fmt.Sprintf("%s\n", trigger.Name))
+			}
+			result.WriteString(fmt.Sprintf("  Event: %s\n", trigger.Event))
+			if len(trigger.Tags) > 0 {
+				result.WriteString(fmt.Sprintf("  Tags: %s\n", strings.Join(trigger.Tags, ", ")))
+			}
+			result.WriteString(fmt.Sprintf("  Function: %s\n", trigger.Function))
+			result.WriteString(fmt.Sprintf("  Owner: %s\n", trigger.Owner))
+			result.WriteString(fmt.Sprintf("  Enabled: %s\n", trigger.Enabled))
+			if trigger.DDL != "" {
+				result.WriteString("  DDL:\n")
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(trigger.DDL, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
 	// Roles (MySQL 8.0+)
 	if len(info.Roles) > 0 {
 		result.WriteString("User Roles (MySQL 8.0+)\n")
@@ -1287,14 +2999,212 @@ func (f *PlaintextFormatter) Format(info *DatabaseInfo) (string, error) {
 		}
 	}
 
+	// Replication information
+	if replication := info.ReplicationInfo; replication != nil {
+		result.WriteString("Replication Information\n")
+		result.WriteString("=======================\n\n")
+		if status := replication.ReplicationStatus; status != nil {
+			result.WriteString(fmt.Sprintf("Server ID: %d\n", status.ServerID))
+			result.WriteString(fmt.Sprintf("Server UUID: %s\n", status.ServerUUID))
+			result.WriteString(fmt.Sprintf("Binary Log Enabled: %t\n", status.LogBinEnabled))
+			result.WriteString(fmt.Sprintf("Binary Log Format: %s\n", status.BinlogFormat))
+			if status.CurrentLogFile != "" {
+				result.WriteString(fmt.Sprintf("Current Binary Log: %s:%d\n", status.CurrentLogFile, status.CurrentLogPos))
+			}
+			result.WriteString(fmt.Sprintf("GTID Mode: %s\n", status.GTIDMode))
+			result.WriteString("\n")
+		}
+		if replication.Role != "" {
+			result.WriteString(fmt.Sprintf("Role: %s\n\n", replication.Role))
+		}
+		if len(replication.Standbys) > 0 {
+			result.WriteString("Standbys:\n")
+			for _, standby := range replication.Standbys {
+				result.WriteString(fmt.Sprintf("  %s (%s)\n", standby.ApplicationName, standby.ClientAddr))
+				result.WriteString(fmt.Sprintf("    State: %s, Sync State: %s\n", standby.State, standby.SyncState))
+				result.WriteString(fmt.Sprintf("    Sent LSN: %s, Replay LSN: %s\n", standby.SentLSN, standby.ReplayLSN))
+				result.WriteString(fmt.Sprintf("    Lag (write/flush/replay): %s / %s / %s\n", standby.WriteLag, standby.FlushLag, standby.ReplayLag))
+			}
+			result.WriteString("\n")
+		}
+		if len(replication.ReplicationSlots) > 0 {
+			result.WriteString("Replication Slots:\n")
+			result.WriteString(fmt.Sprintf("  %-30s %-10s %-15s %-8s %-12s %s\n", "Slot", "Type", "Database", "Active", "WAL Status", "Retained WAL"))
+			for _, slot := range replication.ReplicationSlots {
+				result.WriteString(fmt.Sprintf("  %-30s %-10s %-15s %-8t %-12s %s\n",
+					slot.Name, slot.Type, slot.Database, slot.Active, slot.WALStatus, slot.RetainedWAL))
+			}
+			for _, slot := range replication.ReplicationSlots {
+				if !slot.Active {
+					result.WriteString(fmt.Sprintf("  WARNING: inactive slot %s retains %s of WAL\n", slot.Name, slot.RetainedWAL))
+				}
+			}
+			result.WriteString("\n")
+		}
+		if receiver := replication.WALReceiver; receiver != nil {
+			result.WriteString("WAL Receiver:\n")
+			result.WriteString(fmt.Sprintf("  Status: %s\n", receiver.Status))
+			result.WriteString(fmt.Sprintf("  Sender: %s:%d\n", receiver.SenderHost, receiver.SenderPort))
+			if receiver.SlotName != "" {
+				result.WriteString(fmt.Sprintf("  Slot: %s\n", receiver.SlotName))
+			}
+			result.WriteString(fmt.Sprintf("  Flushed LSN: %s\n", receiver.FlushedLSN))
+			result.WriteString(fmt.Sprintf("  Latest End LSN: %s\n", receiver.LatestEndLSN))
+			result.WriteString(fmt.Sprintf("  Last Message Received: %s\n", receiver.LastMsgReceiptTime))
+			result.WriteString("\n")
+		}
+		if len(replication.Publications) > 0 {
+			result.WriteString("Publications:\n")
+			for _, pub := range replication.Publications {
+				result.WriteString(fmt.Sprintf("  %s (owner: %s)\n", databaseObjectName(pub.Database, pub.Name), pub.Owner))
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(pub.DDL, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+		if len(replication.Subscriptions) > 0 {
+			result.WriteString("Subscriptions:\n")
+			for _, sub := range replication.Subscriptions {
+				result.WriteString(fmt.Sprintf("  %s (owner: %s, enabled: %t)\n", databaseObjectName(sub.Database, sub.Name), sub.Owner, sub.Enabled))
+				result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(sub.DDL, "\n", "\n    ")))
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// Workload
+	if len(info.Workload) > 0 {
+		result.WriteString("Workload\n")
+		result.WriteString("========\n\n")
+		for i, digest := range info.Workload {
+			result.WriteString(fmt.Sprintf("%d. %s\n", i+1, digest.Digest))
+			if digest.Database != "" {
+				result.WriteString(fmt.Sprintf("  Database: %s\n", digest.Database))
+			}
+			if digest.User != "" {
+				result.WriteString(fmt.Sprintf("  User: %s\n", digest.User))
+			}
+			result.WriteString(fmt.Sprintf("  Calls: %d, Total: %.3f ms, Mean: %.3f ms\n", digest.Calls, digest.TotalTimeMs, digest.MeanTimeMs))
+			if digest.RowsExamined > 0 {
+				result.WriteString(fmt.Sprintf("  Rows Examined: %d, Rows Returned: %d\n", digest.RowsExamined, digest.RowsReturned))
+			} else {
+				result.WriteString(fmt.Sprintf("  Rows Returned: %d\n", digest.RowsReturned))
+			}
+			if digest.BlocksHit > 0 || digest.BlocksRead > 0 {
+				result.WriteString(fmt.Sprintf("  Shared Blocks: %d hit, %d read\n", digest.BlocksHit, digest.BlocksRead))
+			}
+			result.WriteString("  Query:\n")
+			result.WriteString(fmt.Sprintf("    %s\n", strings.ReplaceAll(digest.Query, "\n", "\n    ")))
+			result.WriteString("\n")
+		}
+	}
+
+	// Lint findings
+	if info.Lint != nil {
+		result.WriteString("Lint\n")
+		result.WriteString("====\n\n")
+		result.WriteString(fmt.Sprintf("%s\n", findingsSummary(info.Lint)))
+		f.formatFindings(&result, info.Lint)
+		result.WriteString("\n")
+	}
+
+	// Security audit findings
+	if info.Security != nil {
+		result.WriteString("Security Audit\n")
+		result.WriteString("==============\n\n")
+		result.WriteString(fmt.Sprintf("%s\n", findingsSummary(info.Security)))
+		f.formatFindings(&result, info.Security)
+		result.WriteString("\n")
+	}
+
+	// Effective privileges
+	if info.Access != nil {
+		result.WriteString("Effective Privileges\n")
+		result.WriteString("====================\n\n")
+		for _, user := range info.Access.Users {
+			result.WriteString(fmt.Sprintf("%s\n", user.User))
+			result.WriteString(strings.Repeat("-", len(user.User)) + "\n")
+			if user.Superuser {
+				result.WriteString("Superuser: all privileges\n")
+			}
+			if len(user.Roles) > 0 {
+				result.WriteString("Roles:\n")
+				for _, role := range user.Roles {
+					result.WriteString(fmt.Sprintf("  %s\n", rolePath(role)))
+				}
+			}
+			if len(user.Privileges) > 0 {
+				result.WriteString("Privileges:\n")
+				for _, group := range groupEffectivePrivileges(user.Privileges) {
+					result.WriteString(fmt.Sprintf("  %s: %s [%s]\n", group.Object, strings.Join(group.Privileges, ", "), group.Source))
+				}
+			}
+			result.WriteString("\n")
+		}
+		if len(info.Access.Writers) > 0 {
+			result.WriteString("Table Writers:\n")
+			for _, table := range info.Access.Writers {
+				result.WriteString(fmt.Sprintf("  %s\n", table.Table))
+				if len(table.Writers) == 0 {
+					result.WriteString("    -\n")
+				}
+				for _, writer := range table.Writers {
+					result.WriteString(fmt.Sprintf("    %s\n", writerLabel(writer)))
+				}
+			}
+			result.WriteString("\n")
+		}
+	}
+
+	// Upgrade check
+	if info.Upgrade != nil {
+		result.WriteString("Upgrade Check\n")
+		result.WriteString("=============\n\n")
+		result.WriteString(fmt.Sprintf("Upgrade from %s to %s. Errors are blockers.\n", info.Upgrade.Source, info.Upgrade.Target))
+		result.WriteString(fmt.Sprintf("%s\n", findingsSummary(info.Upgrade.Findings)))
+		f.formatFindings(&result, info.Upgrade.Findings)
+		result.WriteString("\n")
+	}
+
+	// Migration check
+	if info.Migration != nil {
+		result.WriteString("Migration Check\n")
+		result.WriteString("===============\n\n")
+		result.WriteString(fmt.Sprintf("Migration from %s to %s. Errors need a manual rewrite, warnings change behavior.\n\n",
+			info.Migration.Source, info.Migration.Target))
+		result.WriteString(fmt.Sprintf("Objects: %s\n", migrationSummary(info.Migration.Objects)))
+		for _, object := range info.Migration.Objects {
+			result.WriteString(fmt.Sprintf("  %-9s %s\n", object.Status, object.Object))
+		}
+		if len(info.Migration.TypeMappings) > 0 {
+			result.WriteString("Type Mappings:\n")
+			for _, mapping := range info.Migration.TypeMappings {
+				result.WriteString(fmt.Sprintf("  %s -> %s (%d columns)\n", mapping.From, mapping.To, mapping.Columns))
+			}
+		}
+		result.WriteString(fmt.Sprintf("Findings: %s\n", findingsSummary(info.Migration.Findings)))
+		f.formatFindings(&result, info.Migration.Findings)
+		result.WriteString("\n")
+	}
+
 	return result.String(), nil
 }
 
-func (f *PlaintextFormatter) filterTables(tables []TableInfo, tableType string) []TableInfo {
+// formatFindings writes one indented line per finding
+func (f *PlaintextFormatter) formatFindings(result *strings.Builder, findings []Finding) {
+	for _, finding := range findings {
+		result.WriteString(fmt.Sprintf("  [%s] %s %s: %s\n", strings.ToUpper(string(finding.Severity)),
+			finding.Rule, finding.Object, finding.Message))
+	}
+}
+
+func (f *PlaintextFormatter) filterTables(tables []TableInfo, tableTypes ...string) []TableInfo {
 	var filtered []TableInfo
 	for _, table := range tables {
-		if table.Type == tableType {
-			filtered = append(filtered, table)
+		for _, tableType := range tableTypes {
+			if table.Type == tableType {
+				filtered = append(filtered, table)
+				break
+			}
 		}
 	}
 	return filtered
@@ -1327,9 +3237,18 @@ func (f *PlaintextFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(info.Tables) > 0 {
 		sections = append(sections, "Tables - Database tables with metadata and DDL definitions")
 	}
+	if hasTableStats(info.Tables) {
+		sections = append(sections, "Table Statistics - Row estimates, sizes, index usage and statistics")
+	}
 	if f.hasViews(info.Tables) {
 		sections = append(sections, "View Details - Database views with their definitions")
 	}
+	if len(f.filterTables(info.Tables, "SEQUENCE")) > 0 {
+		sections = append(sections, "Sequences - PostgreSQL sequences with their current values")
+	}
+	if len(info.Types) > 0 {
+		sections = append(sections, "Types - User-defined types, domains and collations")
+	}
 	functions := f.filterRoutines(info.Routines, "FUNCTION")
 	if len(functions) > 0 {
 		sections = append(sections, "Stored Functions - User-defined functions with their definitions")
@@ -1338,6 +3257,15 @@ func (f *PlaintextFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if len(procedures) > 0 {
 		sections = append(sections, "Stored Procedures - User-defined procedures with their definitions")
 	}
+	if len(info.Triggers) > 0 {
+		sections = append(sections, "Triggers - Table triggers with their definitions")
+	}
+	if len(info.Events) > 0 {
+		sections = append(sections, "Events - Scheduled events with their schedules and definitions")
+	}
+	if len(info.EventTriggers) > 0 {
+		sections = append(sections, "Event Triggers - Database-wide DDL event triggers")
+	}
 	if len(info.Roles) > 0 {
 		sections = append(sections, "User Roles - Role definitions and assignments")
 	}
@@ -1356,14 +3284,57 @@ func (f *PlaintextFormatter) generateSectionsList(info *DatabaseInfo) []string {
 	if info.ReplicationInfo != nil {
 		sections = append(sections, "Replication Info - Replication configuration and status")
 	}
+	if len(info.Workload) > 0 {
+		sections = append(sections, "Workload - Top statements by total execution time")
+	}
+	if info.Lint != nil {
+		sections = append(sections, "Lint - Schema lint findings by severity")
+	}
+	if info.Security != nil {
+		sections = append(sections, "Security Audit - Account and privilege findings by severity")
+	}
+	if info.Access != nil {
+		sections = append(sections, "Effective Privileges - Privileges of each user through role grants, and table writers")
+	}
+	if info.Upgrade != nil {
+		sections = append(sections, "Upgrade Check - Blockers and warnings for the target version")
+	}
+	if info.Migration != nil {
+		sections = append(sections, "Migration Check - How tables, views and stored programs translate to the target database")
+	}
 	return sections
 }
 
 func (f *PlaintextFormatter) hasViews(tables []TableInfo) bool {
 	for _, table := range tables {
-		if table.Type == "VIEW" {
+		if table.Type == "VIEW" || table.Type == "MATERIALIZED VIEW" {
 			return true
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+// JSONFormatter formats output as JSON
+type JSONFormatter struct{}
+
+// JSONDocument is the top-level JSON object: the schema version followed by
+// every DatabaseInfo field.
+type JSONDocument struct {
+	SchemaVersion string `json:"schema_version"`
+	*DatabaseInfo
+}
+
+func (f *JSONFormatter) Format(info *DatabaseInfo) (string, error) {
+	doc := JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		DatabaseInfo:  info,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func (f *JSONFormatter) GetFileExtension() string {
+	return ".json"
+}
@@ -2,9 +2,9 @@ module github.com/tom--bo/databasemix
 
 go 1.22
 
-require github.com/go-sql-driver/mysql v1.9.3
-
 require (
-	filippo.io/edwards25519 v1.1.0 // indirect
-	github.com/lib/pq v1.12.3 // indirect
+	github.com/go-sql-driver/mysql v1.9.3
+	github.com/lib/pq v1.12.3
 )
+
+require filippo.io/edwards25519 v1.1.0 // indirect
@@ -0,0 +1,533 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The lint engine runs a set of rules over a collected DatabaseInfo and reports
+// findings with a severity. Rules that look at table structure work on the
+// columns, indexes and constraints collected from the catalogs, or on the parsed
+// table DDL for snapshots taken before those were collected (see ddl_parser.go).
+
+// Severity of a finding
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// rank orders severities from info (1) to error (3)
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	}
+	return 0
+}
+
+// ParseSeverity parses info, warning or error
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(s)))
+	if severity.rank() == 0 {
+		return "", fmt.Errorf("unknown severity '%s'. Valid values: info, warning, error", s)
+	}
+	return severity, nil
+}
+
+// Finding is one problem reported by a rule. Reports hold their findings in a
+// non-nil slice, empty when nothing was found, so that the formatters still
+// show the section.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Object   string   `json:"object"`
+	Message  string   `json:"message"`
+}
+
+// HasFindingsAtLeast reports whether any finding is at or above the given severity
+func HasFindingsAtLeast(findings []Finding, min Severity) bool {
+	for _, finding := range findings {
+		if finding.Severity.rank() >= min.rank() {
+			return true
+		}
+	}
+	return false
+}
+
+// sortFindings orders findings by severity (highest first), object and rule
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity.rank() != b.Severity.rank() {
+			return a.Severity.rank() > b.Severity.rank()
+		}
+		if a.Object != b.Object {
+			return a.Object < b.Object
+		}
+		return a.Rule < b.Rule
+	})
+}
+
+// countFindings returns the number of findings per severity
+func countFindings(findings []Finding) map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, finding := range findings {
+		counts[finding.Severity]++
+	}
+	return counts
+}
+
+// findingsSummary describes the number of findings per severity, e.g. "1 error, 2 warnings, 0 info"
+func findingsSummary(findings []Finding) string {
+	counts := countFindings(findings)
+	plural := func(n int, word string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, word)
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	}
+	return fmt.Sprintf("%s, %s, %d info", plural(counts[SeverityError], "error"),
+		plural(counts[SeverityWarning], "warning"), counts[SeverityInfo])
+}
+
+// lintTable is a base table with its structure
+type lintTable struct {
+	info      *TableInfo
+	structure *ddlTable
+	withDB    bool // include the database in the name (several PostgreSQL databases)
+}
+
+func (t lintTable) name() string {
+	if t.withDB {
+		return qualifiedName(t.info.Database, t.info.Schema, t.info.Name)
+	}
+	return t.info.Schema + "." + t.info.Name
+}
+
+// baseTables returns the base tables with their structure. PostgreSQL partitions
+// are left out: their columns, keys and constraints are those of the parent table.
+func baseTables(info *DatabaseInfo) []lintTable {
+	postgres := info.DBType == "postgres"
+	// MySQL databases are schemas, so only PostgreSQL names need the database
+	withDB := postgres && spansDatabases(info.Tables)
+
+	var tables []lintTable
+	for i := range info.Tables {
+		table := &info.Tables[i]
+		if table.Type != "BASE TABLE" || table.PartitionOf != "" {
+			continue
+		}
+		if len(table.Columns) > 0 {
+			tables = append(tables, lintTable{info: table, structure: tableStructure(table, postgres), withDB: withDB})
+			continue
+		}
+		// Snapshots taken before the columns were collected from the catalogs have only the DDL
+		if parsed := parseTableDDL(table.DDL); parsed != nil {
+			tables = append(tables, lintTable{info: table, structure: parsed, withDB: withDB})
+		}
+	}
+	return tables
+}
+
+// dropTableStructure removes partly collected columns, indexes and constraints, so
+// that the consumers parse the DDL of every table instead
+func dropTableStructure(tables []TableInfo) {
+	for i := range tables {
+		tables[i].Columns = nil
+		tables[i].Indexes = nil
+		tables[i].ForeignKeys = nil
+		tables[i].CheckConstraints = nil
+	}
+}
+
+// mysqlKeyPrefixRe matches the prefix length of a MySQL key part, e.g. name(10)
+var mysqlKeyPrefixRe = regexp.MustCompile(`^(.+)\(\d+\)$`)
+
+// tableStructure converts the columns, indexes and constraints collected from the
+// catalogs to the form parseTableDDL returns, so that the rules see the same values
+// for both: key parts without prefix lengths and sort orders, upper-case index
+// methods, column character sets and collations only where they differ from the
+// table's, and referential actions only where they are not NO ACTION.
+func tableStructure(table *TableInfo, postgres bool) *ddlTable {
+	structure := &ddlTable{Engine: table.Engine, Charset: table.Charset, Collation: table.Collation}
+
+	for _, column := range table.Columns {
+		charset, collation := column.Charset, column.Collation
+		if !postgres && strings.EqualFold(charset, table.Charset) {
+			charset = ""
+		}
+		if !postgres && strings.EqualFold(collation, table.Collation) {
+			collation = ""
+		}
+		structure.Columns = append(structure.Columns, ddlColumn{
+			Name:          column.Name,
+			Type:          lowerOutsideQuotes(column.Type),
+			Nullable:      column.Nullable,
+			Default:       column.Default,
+			Charset:       charset,
+			Collation:     collation,
+			AutoIncrement: column.AutoIncrement,
+			Identity:      column.Identity,
+			Generated:     column.Generated,
+			OnUpdate:      strings.ToUpper(column.OnUpdate),
+			Comment:       column.Comment,
+		})
+	}
+
+	for _, index := range table.Indexes {
+		var columns []string
+		for _, part := range index.Columns {
+			part = ddlKeyPartOrderRe.ReplaceAllString(part, "")
+			switch {
+			case postgres:
+				// pg_get_indexdef quotes names as in the DDL
+				part = unquoteIdent(part)
+			case !strings.HasPrefix(part, "("):
+				if m := mysqlKeyPrefixRe.FindStringSubmatch(part); m != nil {
+					part = m[1]
+				}
+			}
+			columns = append(columns, part)
+		}
+		structure.Indexes = append(structure.Indexes, ddlIndex{
+			Name:    index.Name,
+			Columns: columns,
+			Primary: index.Primary,
+			Unique:  index.Unique,
+			Method:  strings.ToUpper(index.Method),
+			Partial: index.Predicate != "",
+		})
+	}
+
+	for _, fk := range table.ForeignKeys {
+		// As written in the DDL: schema-qualified on PostgreSQL, and on MySQL only across schemas
+		refTable := fk.RefTable
+		if postgres || fk.RefSchema != table.Schema {
+			refTable = fk.RefSchema + "." + fk.RefTable
+		}
+		action := func(rule string) string {
+			if rule == "NO ACTION" {
+				return ""
+			}
+			return rule
+		}
+		structure.ForeignKeys = append(structure.ForeignKeys, ddlForeignKey{
+			Name:       fk.Name,
+			Columns:    fk.Columns,
+			RefTable:   refTable,
+			RefColumns: fk.RefColumns,
+			OnDelete:   action(fk.OnDelete),
+			OnUpdate:   action(fk.OnUpdate),
+		})
+	}
+
+	for _, check := range table.CheckConstraints {
+		// PostgreSQL reports the expression without the parentheses of CHECK (...)
+		expression := check.Expression
+		if !strings.HasPrefix(expression, "(") || matchingParen(expression, 0) != len(expression)-1 {
+			expression = "(" + expression + ")"
+		}
+		structure.Checks = append(structure.Checks, ddlCheck{Name: check.Name, Expression: expression})
+	}
+	return structure
+}
+
+// lintRule is one built-in rule
+type lintRule struct {
+	id          string
+	severity    Severity
+	dbType      string // "mysql", "postgres" or "" for both
+	description string
+	check       func(info *DatabaseInfo, tables []lintTable) []lintResult
+}
+
+// lintResult is what a rule reports; the engine adds the rule id and severity
+type lintResult struct {
+	object  string
+	message string
+}
+
+var lintRules = []lintRule{
+	{
+		id:          "no-primary-key",
+		severity:    SeverityError,
+		description: "Table has no primary key",
+		check:       checkNoPrimaryKey,
+	},
+	{
+		id:          "fk-without-index",
+		severity:    SeverityWarning,
+		description: "Foreign key columns are not the leading columns of any index",
+		check:       checkForeignKeyIndexes,
+	},
+	{
+		id:          "duplicate-index",
+		severity:    SeverityWarning,
+		description: "Index has the same columns as another index",
+		check:       checkDuplicateIndexes,
+	},
+	{
+		id:          "redundant-index",
+		severity:    SeverityInfo,
+		description: "Non-unique index is a left prefix of another index",
+		check:       checkRedundantIndexes,
+	},
+	{
+		id:          "legacy-charset",
+		severity:    SeverityWarning,
+		dbType:      "mysql",
+		description: "utf8mb3 or latin1 table or column in a schema that uses utf8mb4",
+		check:       checkLegacyCharsets,
+	},
+	{
+		id:          "myisam-table",
+		severity:    SeverityWarning,
+		dbType:      "mysql",
+		description: "Table uses the non-transactional MyISAM engine",
+		check:       checkMyISAM,
+	},
+	{
+		id:          "float-money",
+		severity:    SeverityWarning,
+		description: "Monetary column uses an approximate FLOAT/DOUBLE type",
+		check:       checkFloatMoney,
+	},
+	{
+		id:          "serial-column",
+		severity:    SeverityInfo,
+		dbType:      "postgres",
+		description: "Column uses a serial sequence default instead of an identity column",
+		check:       checkSerialColumns,
+	},
+}
+
+// Lint runs all built-in rules that apply to the database type and returns the sorted findings
+func Lint(info *DatabaseInfo) []Finding {
+	tables := baseTables(info)
+
+	findings := []Finding{}
+	for _, rule := range lintRules {
+		if rule.dbType != "" && rule.dbType != info.DBType {
+			continue
+		}
+		for _, result := range rule.check(info, tables) {
+			findings = append(findings, Finding{
+				Severity: rule.severity,
+				Rule:     rule.id,
+				Object:   result.object,
+				Message:  result.message,
+			})
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+func checkNoPrimaryKey(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		if table.structure.primaryKey() != nil {
+			continue
+		}
+		message := "table has no primary key"
+		for _, index := range table.structure.Indexes {
+			if index.Unique && !index.Partial && allNotNull(table.structure, index.Columns) {
+				message += fmt.Sprintf("; unique index %s on NOT NULL columns could be promoted", index.Name)
+				break
+			}
+		}
+		results = append(results, lintResult{table.name(), message})
+	}
+	return results
+}
+
+func checkForeignKeyIndexes(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		for _, fk := range table.structure.ForeignKeys {
+			supported := false
+			for _, index := range table.structure.Indexes {
+				if !index.Partial && coversLeadingColumns(index.Columns, fk.Columns) {
+					supported = true
+					break
+				}
+			}
+			if !supported {
+				results = append(results, lintResult{table.name(), fmt.Sprintf(
+					"foreign key %s (%s) has no supporting index; deletes and updates on %s scan this table",
+					fk.Name, strings.Join(fk.Columns, ", "), fk.RefTable)})
+			}
+		}
+	}
+	return results
+}
+
+func checkDuplicateIndexes(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		indexes := table.structure.Indexes
+		for i := range indexes {
+			for j := i + 1; j < len(indexes); j++ {
+				a, b := indexes[i], indexes[j]
+				if a.Method != b.Method || a.Partial || b.Partial || !equalStrings(a.Columns, b.Columns) {
+					continue
+				}
+				// Report the index that can be dropped: keep the primary or unique one
+				keep, drop := a, b
+				if !a.Unique && b.Unique {
+					keep, drop = b, a
+				}
+				if drop.Unique && !keep.Primary {
+					continue // two unique constraints; one of them may be referenced by a foreign key
+				}
+				results = append(results, lintResult{table.name(), fmt.Sprintf(
+					"index %s duplicates %s (%s)", drop.Name, keep.Name, strings.Join(keep.Columns, ", "))})
+			}
+		}
+	}
+	return results
+}
+
+func checkRedundantIndexes(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		indexes := table.structure.Indexes
+		for _, index := range indexes {
+			if index.Unique || index.Partial {
+				continue
+			}
+			for _, other := range indexes {
+				if other.Method != index.Method || other.Partial || len(other.Columns) <= len(index.Columns) {
+					continue
+				}
+				if equalStrings(other.Columns[:len(index.Columns)], index.Columns) {
+					results = append(results, lintResult{table.name(), fmt.Sprintf(
+						"index %s (%s) is a left prefix of %s (%s)", index.Name, strings.Join(index.Columns, ", "),
+						other.Name, strings.Join(other.Columns, ", "))})
+					break
+				}
+			}
+		}
+	}
+	return results
+}
+
+// legacyCharsets are character sets that cannot store all of Unicode
+var legacyCharsets = map[string]bool{"utf8": true, "utf8mb3": true, "latin1": true}
+
+func checkLegacyCharsets(info *DatabaseInfo, tables []lintTable) []lintResult {
+	// A schema "uses utf8mb4" when any of its tables defaults to it
+	utf8mb4Schemas := make(map[string]bool)
+	for _, table := range tables {
+		if strings.EqualFold(table.structure.Charset, "utf8mb4") {
+			utf8mb4Schemas[table.info.Schema] = true
+		}
+	}
+
+	var results []lintResult
+	for _, table := range tables {
+		if !utf8mb4Schemas[table.info.Schema] {
+			continue
+		}
+		if charset := strings.ToLower(table.structure.Charset); legacyCharsets[charset] {
+			results = append(results, lintResult{table.name(), fmt.Sprintf("table default character set is %s", charset)})
+		}
+		for _, column := range table.structure.Columns {
+			if charset := strings.ToLower(column.Charset); legacyCharsets[charset] {
+				results = append(results, lintResult{table.name() + "." + column.Name,
+					fmt.Sprintf("column character set is %s", charset)})
+			}
+		}
+	}
+	return results
+}
+
+func checkMyISAM(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		if strings.EqualFold(table.info.Engine, "MyISAM") {
+			results = append(results, lintResult{table.name(), "table uses MyISAM (no transactions, no crash safety, table-level locks)"})
+		}
+	}
+	return results
+}
+
+var (
+	moneyColumnRe = regexp.MustCompile(`(?i)(price|amount|cost|total|balance|fee|salary|wage|money|tax|payment|revenue)`)
+	floatTypeRe   = regexp.MustCompile(`^(float|double|real|double precision)\b`)
+)
+
+func checkFloatMoney(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		for _, column := range table.structure.Columns {
+			if floatTypeRe.MatchString(column.Type) && moneyColumnRe.MatchString(column.Name) {
+				results = append(results, lintResult{table.name() + "." + column.Name, fmt.Sprintf(
+					"column type %s is approximate; use DECIMAL/NUMERIC for monetary values", column.Type)})
+			}
+		}
+	}
+	return results
+}
+
+func checkSerialColumns(info *DatabaseInfo, tables []lintTable) []lintResult {
+	var results []lintResult
+	for _, table := range tables {
+		for _, column := range table.structure.Columns {
+			if strings.HasPrefix(column.Default, "nextval(") {
+				results = append(results, lintResult{table.name() + "." + column.Name,
+					"serial column; consider GENERATED ... AS IDENTITY"})
+			}
+		}
+	}
+	return results
+}
+
+// coversLeadingColumns reports whether the first len(columns) index columns are
+// exactly the given columns, in any order
+func coversLeadingColumns(indexColumns, columns []string) bool {
+	if len(indexColumns) < len(columns) {
+		return false
+	}
+	leading := make(map[string]bool)
+	for _, column := range indexColumns[:len(columns)] {
+		leading[column] = true
+	}
+	for _, column := range columns {
+		if !leading[column] {
+			return false
+		}
+	}
+	return true
+}
+
+func allNotNull(table *ddlTable, columns []string) bool {
+	for _, name := range columns {
+		column := table.findColumn(name)
+		if column == nil || column.Nullable {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// partitionedLogs is a partitioned table and one of its partitions, as collected from PostgreSQL
+func partitionedLogs() *DatabaseInfo {
+	return &DatabaseInfo{
+		DBType: "postgres",
+		Tables: []TableInfo{
+			{
+				Database:     "app",
+				Schema:       "public",
+				Name:         "logs",
+				Type:         "BASE TABLE",
+				PartitionKey: "RANGE (log_date)",
+				DDL: "CREATE TABLE public.logs (\n" +
+					"    id integer NOT NULL,\n" +
+					"    log_date date NOT NULL,\n" +
+					"    message text,\n" +
+					"    CONSTRAINT logs_pkey PRIMARY KEY (id, log_date)\n" +
+					") PARTITION BY RANGE (log_date);",
+			},
+			{
+				Database:       "app",
+				Schema:         "public",
+				Name:           "logs_2024",
+				Type:           "BASE TABLE",
+				PartitionOf:    "public.logs",
+				PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+				DDL: "CREATE TABLE public.logs_2024 PARTITION OF public.logs\n" +
+					"    FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');",
+			},
+		},
+	}
+}
+
+func TestParseTableDDLPartition(t *testing.T) {
+	info := partitionedLogs()
+	if parsed := parseTableDDL(info.Tables[1].DDL); parsed != nil {
+		t.Errorf("parseTableDDL(partition) = %+v, want nil", parsed.Columns)
+	}
+	if parsed := parseTableDDL(info.Tables[0].DDL); parsed == nil || len(parsed.Columns) != 3 {
+		t.Errorf("parseTableDDL(partitioned table) did not return its 3 columns")
+	}
+}
+
+func TestLintSkipsPartitions(t *testing.T) {
+	info := partitionedLogs()
+	for _, table := range baseTables(info) {
+		if table.info.PartitionOf != "" {
+			t.Errorf("baseTables returned partition %s", table.name())
+		}
+	}
+	for _, finding := range Lint(info) {
+		if strings.Contains(finding.Object, "logs_2024") {
+			t.Errorf("unexpected finding for a partition: %s %s %s", finding.Severity, finding.Rule, finding.Object)
+		}
+	}
+}
@@ -27,16 +27,41 @@ type Config struct {
 	OutputFile             string
 	ExceptTables           bool
 	ExceptStoredProcedures bool
+	ExceptTriggers         bool // MySQL triggers / PostgreSQL event triggers
+	ExceptEvents           bool // MySQL only
 	ExceptVariables        bool
 	OnlyModifiedVariables  bool
 	ExceptUsers            bool
 	ExceptRoles            bool
 	ExceptPlugins          bool
-	ExceptExtensions       bool // PostgreSQL only
+	ExceptExtensions       bool   // PostgreSQL only
+	ExceptTypes            bool   // PostgreSQL only
+	Stats                  bool   // Table and index statistics
+	Workload               bool   // Top statement digests
+	WorkloadLimit          int    // Number of statement digests to collect
+	Lint                   bool   // Run the schema lint rules
+	FailOn                 string // Exit non-zero when a lint or security finding has at least this severity
+	SecurityAudit          bool   // Audit accounts, roles and privileges
+	EffectivePrivileges    bool   // Resolve privileges through the role graph
+	UpgradeCheck           string // Check readiness for upgrading to this version
+	MigrationCheck         string // Check how the schema translates to this database type
 	TLS                    string // TLS/SSL mode: MySQL(false,true,skip-verify,preferred), PostgreSQL(disable,require,verify-ca,verify-full)
+	SnapshotFile           string // Write collected information to this snapshot file
+	FromSnapshot           string // Render from this snapshot file instead of connecting
+	DriftDir               string // Compare DDL against the .sql files in this directory
+	TranslateDDL           string // Print the schema translated to this database type
+	DataDictionary         bool   // Write a Markdown data dictionary instead of the report
 }
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:]); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
 	// Parse command line arguments
 	config, err := parseFlags()
 	if err != nil {
@@ -44,44 +69,87 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Connect to database and create collector based on DB type
-	var db *sql.DB
-	var collector Collector
-
-	switch config.DBType {
-	case "mysql":
-		db, err = connectToMySQL(config)
+	// Load a saved snapshot, or connect to the database and collect everything
+	var info *DatabaseInfo
+	if config.FromSnapshot != "" {
+		info, err = LoadSnapshot(config.FromSnapshot)
 		if err != nil {
-			log.Fatalf("Failed to connect to MySQL: %v", err)
+			log.Fatalf("Failed to load snapshot: %v", err)
 		}
-		defer db.Close()
-		collector, err = NewMySQLCollector(db, config)
+	} else {
+		info, err = collectDatabaseInfo(config)
 		if err != nil {
-			log.Fatalf("Failed to create MySQL collector: %v", err)
+			log.Fatalf("%v", err)
 		}
-	case "postgres":
-		db, err = connectToPostgreSQL(config)
+	}
+
+	// Save a snapshot for offline re-rendering if requested
+	if config.SnapshotFile != "" {
+		if err := SaveSnapshot(info, config.SnapshotFile); err != nil {
+			log.Fatalf("Failed to write snapshot: %v", err)
+		}
+		fmt.Printf("Snapshot has been written to %s\n", config.SnapshotFile)
+	}
+
+	// Drift detection mode: report to stdout and exit non-zero on drift
+	if config.DriftDir != "" {
+		result, err := CheckDrift(info, config.DriftDir)
 		if err != nil {
-			log.Fatalf("Failed to connect to PostgreSQL: %v", err)
+			log.Fatalf("Failed to check drift: %v", err)
 		}
-		defer db.Close()
-		collector, err = NewPostgreSQLCollector(db, config)
+		output, err := FormatDrift(result, OutputFormat(config.Format))
 		if err != nil {
-			log.Fatalf("Failed to create PostgreSQL collector: %v", err)
+			log.Fatalf("Failed to format drift report: %v", err)
 		}
+		fmt.Print(output)
+		if result.HasDrift() {
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Collect all database information
-	info, err := collector.CollectAll()
-	if err != nil {
-		log.Fatalf("Failed to collect database information: %v", err)
+	// DDL translation mode: print the translated statements to stdout
+	if config.TranslateDDL != "" {
+		ddl, err := TranslateDDL(info, config.TranslateDDL)
+		if err != nil {
+			log.Fatalf("Failed to translate DDL: %v", err)
+		}
+		fmt.Print(ddl)
+		return
 	}
 
-	// Create formatter based on requested format
-	format := OutputFormat(config.Format)
-	formatter, err := NewFormatter(format)
-	if err != nil {
-		log.Fatalf("Failed to create formatter: %v", err)
+	// Lint findings are derived from the collected information, so they are not part of the snapshot
+	if config.Lint {
+		info.Lint = Lint(info)
+	}
+	if config.SecurityAudit {
+		info.Security = AuditSecurity(info, time.Now())
+	}
+	if config.EffectivePrivileges {
+		info.Access = ResolveAccess(info)
+	}
+	if config.UpgradeCheck != "" {
+		info.Upgrade, err = CheckUpgrade(info, config.UpgradeCheck)
+		if err != nil {
+			log.Fatalf("Failed to check upgrade: %v", err)
+		}
+	}
+	if config.MigrationCheck != "" {
+		info.Migration, err = CheckMigration(info, config.MigrationCheck)
+		if err != nil {
+			log.Fatalf("Failed to check migration: %v", err)
+		}
+	}
+
+	// Create formatter based on requested format; the data dictionary replaces the report
+	var formatter Formatter
+	if config.DataDictionary {
+		formatter = &DataDictionaryFormatter{}
+	} else {
+		formatter, err = NewFormatter(OutputFormat(config.Format))
+		if err != nil {
+			log.Fatalf("Failed to create formatter: %v", err)
+		}
 	}
 
 	// Format the output
@@ -100,6 +168,14 @@ func main() {
 			extension = ".xml"
 		case "plaintext":
 			extension = ".txt"
+		case "json":
+			extension = ".json"
+		case "mermaid":
+			extension = ".mmd"
+		case "dot":
+			extension = ".dot"
+		case "plantuml":
+			extension = ".puml"
 		default: // markdown
 			extension = ".md"
 		}
@@ -122,6 +198,60 @@ func main() {
 		}
 		fmt.Printf("Database information has been written to %s\n", outputFile)
 	}
+
+	if config.FailOn != "" {
+		failed := false
+		if HasFindingsAtLeast(info.Lint, Severity(config.FailOn)) {
+			fmt.Fprintf(os.Stderr, "Lint failed: %s (threshold: %s)\n", findingsSummary(info.Lint), config.FailOn)
+			failed = true
+		}
+		if HasFindingsAtLeast(info.Security, Severity(config.FailOn)) {
+			fmt.Fprintf(os.Stderr, "Security audit failed: %s (threshold: %s)\n", findingsSummary(info.Security), config.FailOn)
+			failed = true
+		}
+		if failed {
+			os.Exit(1)
+		}
+	}
+}
+
+// collectDatabaseInfo connects to the database described by config and collects all information
+func collectDatabaseInfo(config *Config) (*DatabaseInfo, error) {
+	var db *sql.DB
+	var collector Collector
+	var err error
+
+	switch config.DBType {
+	case "mysql":
+		db, err = connectToMySQL(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to MySQL: %v", err)
+		}
+		defer db.Close()
+		collector, err = NewMySQLCollector(db, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create MySQL collector: %v", err)
+		}
+	case "postgres":
+		db, err = connectToPostgreSQL(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to PostgreSQL: %v", err)
+		}
+		defer db.Close()
+		collector, err = NewPostgreSQLCollector(db, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create PostgreSQL collector: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported database type '%s'", config.DBType)
+	}
+
+	// Collect all database information
+	info, err := collector.CollectAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect database information: %v", err)
+	}
+	return info, nil
 }
 
 func setDefaultConfigFromEnv(config *Config) {
@@ -170,17 +300,34 @@ func parseFlags() (*Config, error) {
 	flag.StringVar(&config.User, "user", "", "Database user (default: root for mysql, postgres for postgres)")
 	flag.StringVar(&config.Password, "password", "", "Database password")
 	flag.StringVar(&config.Database, "database", "", "Database name (if not specified, all accessible databases will be analyzed)")
-	flag.BoolVar(&config.Replication, "replication", false, "Include replication information (MySQL only)")
+	flag.BoolVar(&config.Replication, "replication", false, "Include replication information")
 	flag.BoolVar(&config.ExceptTables, "except-tables", false, "Exclude tables and views")
 	flag.BoolVar(&config.ExceptStoredProcedures, "except-stored-procedures", false, "Exclude stored procedures and functions")
+	flag.BoolVar(&config.ExceptTriggers, "except-triggers", false, "Exclude triggers (MySQL) / event triggers (PostgreSQL)")
+	flag.BoolVar(&config.ExceptEvents, "except-events", false, "Exclude scheduled events (MySQL only)")
 	flag.BoolVar(&config.ExceptVariables, "except-variables", false, "Exclude variables/configuration parameters")
 	flag.BoolVar(&config.OnlyModifiedVariables, "only-modified-variables", false, "Show only modified variables (default: show all)")
 	flag.BoolVar(&config.ExceptUsers, "except-users", false, "Exclude user accounts")
 	flag.BoolVar(&config.ExceptRoles, "except-roles", false, "Exclude user roles")
 	flag.BoolVar(&config.ExceptPlugins, "except-plugins", false, "Exclude installed plugins (MySQL only)")
 	flag.BoolVar(&config.ExceptExtensions, "except-extensions", false, "Exclude installed extensions (PostgreSQL only)")
+	flag.BoolVar(&config.Stats, "stats", false, "Include table and index statistics (row estimates, sizes, index cardinality, histograms)")
+	flag.BoolVar(&config.Workload, "workload", false, "Include the top statement digests by total time (performance_schema / pg_stat_statements)")
+	flag.IntVar(&config.WorkloadLimit, "workload-limit", 20, "Number of statement digests to include with -workload")
+	flag.BoolVar(&config.Lint, "lint", false, "Run the built-in schema lint rules and add a Lint section")
+	flag.StringVar(&config.FailOn, "fail-on", "", "Exit with status 1 when a lint or security finding has at least this severity: info, warning, error (implies -lint)")
+	flag.BoolVar(&config.SecurityAudit, "security-audit", false, "Audit user accounts, roles and privileges and add a Security Audit section")
+	flag.StringVar(&config.UpgradeCheck, "upgrade-check", "", "Report upgrade blockers and warnings for this target version (MySQL: 8.0, 8.4 / PostgreSQL: 17, 18)")
+	flag.StringVar(&config.MigrationCheck, "migration-check", "", "Report how tables, views and stored programs translate to this database type (MySQL to postgres)")
+	flag.BoolVar(&config.EffectivePrivileges, "effective-privileges", false, "Resolve each user's privileges through role grants and list who can write to each table")
+	flag.BoolVar(&config.ExceptTypes, "except-types", false, "Exclude user-defined types, domains and collations (PostgreSQL only)")
 	flag.StringVar(&config.TLS, "tls", "", "TLS/SSL mode (MySQL: false,true,skip-verify,preferred / PostgreSQL: disable,require,verify-ca,verify-full)")
-	flag.StringVar(&config.Format, "format", "markdown", "Output format: markdown, xml, plaintext")
+	flag.StringVar(&config.Format, "format", "markdown", "Output format: markdown, xml, plaintext, json, mermaid, dot, plantuml")
+	flag.StringVar(&config.SnapshotFile, "snapshot", "", "Also save the collected information to this snapshot file (JSON)")
+	flag.StringVar(&config.FromSnapshot, "from-snapshot", "", "Render output from a saved snapshot file instead of connecting to a database")
+	flag.StringVar(&config.DriftDir, "drift-dir", "", "Compare table/view DDL and routine definitions against the .sql files in this directory and exit non-zero on drift")
+	flag.StringVar(&config.TranslateDDL, "translate-ddl", "", "Print best-effort CREATE TABLE/INDEX/VIEW statements translated to this database type: postgres, mysql")
+	flag.BoolVar(&config.DataDictionary, "data-dictionary", false, "Write a Markdown data dictionary of the tables, views, columns, types and routines with their comments instead of the report")
 	flag.StringVar(&config.OutputFile, "outfile", "dbmix-output", "Output filename (if not specified, output goes to stdout)")
 
 	flag.Parse()
@@ -237,6 +384,26 @@ func parseFlags() (*Config, error) {
 		}
 	}
 
+	if config.FailOn != "" {
+		severity, err := ParseSeverity(config.FailOn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -fail-on: %v", err)
+		}
+		config.FailOn = string(severity)
+		config.Lint = true
+	}
+
+	if config.SecurityAudit && config.ExceptUsers {
+		return nil, fmt.Errorf("-security-audit needs the user accounts and cannot be combined with -except-users")
+	}
+	if config.EffectivePrivileges && (config.ExceptUsers || config.ExceptRoles) {
+		return nil, fmt.Errorf("-effective-privileges needs users and roles and cannot be combined with -except-users or -except-roles")
+	}
+
+	if config.WorkloadLimit <= 0 {
+		return nil, fmt.Errorf("-workload-limit must be a positive number, got %d", config.WorkloadLimit)
+	}
+
 	// Validate format and set default to markdown
 	format := strings.ToLower(strings.TrimSpace(config.Format))
 	switch format {
@@ -246,10 +413,26 @@ func parseFlags() (*Config, error) {
 		config.Format = "xml"
 	case "plaintext", "text", "txt":
 		config.Format = "plaintext"
+	case "json":
+		config.Format = "json"
+	case "mermaid", "mmd":
+		config.Format = "mermaid"
+	case "dot", "graphviz":
+		config.Format = "dot"
+	case "plantuml", "puml":
+		config.Format = "plantuml"
 	default:
 		fmt.Printf("Warning: Unsupported format '%s'. Using default 'markdown' format.\n", config.Format)
 		config.Format = "markdown"
 	}
+	if config.DataDictionary && config.Format != "markdown" {
+		fmt.Printf("Warning: The data dictionary is written as Markdown; ignoring format '%s'.\n", config.Format)
+		config.Format = "markdown"
+	}
+	if config.DriftDir != "" && config.Format != "markdown" && config.Format != "plaintext" && config.Format != "json" {
+		fmt.Printf("Warning: The drift report is written as Markdown, plaintext or JSON; using plaintext instead of format '%s'.\n", config.Format)
+		config.Format = "plaintext"
+	}
 
 	return config, nil
 }
@@ -306,158 +489,534 @@ func connectToPostgreSQL(config *Config) (*sql.DB, error) {
 
 // Connection information for display
 type ConnectionInfo struct {
-	Host     string
-	Port     string
-	User     string
-	Database string
-	Version  string
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Database string `json:"database"`
+	Version  string `json:"version"`
 }
 
 // Main data structure that holds all database information
 type DatabaseInfo struct {
-	DBType          string // "mysql" or "postgres"
-	ConnectionInfo  *ConnectionInfo
-	Tables          []TableInfo
-	Users           []UserAccount
-	Routines        []RoutineInfo
-	Variables       []Variable
-	Roles           []UserRole
-	Plugins         []Plugin
-	Components      []Component
-	ReplicationInfo *ReplicationInfo
-	Extensions      []Extension // PostgreSQL only
+	DBType          string             `json:"db_type"` // "mysql" or "postgres"
+	ConnectionInfo  *ConnectionInfo    `json:"connection_info,omitempty"`
+	Tables          []TableInfo        `json:"tables,omitempty"`
+	Users           []UserAccount      `json:"users,omitempty"`
+	Routines        []RoutineInfo      `json:"routines,omitempty"`
+	Triggers        []TriggerInfo      `json:"triggers,omitempty"`       // MySQL only
+	Events          []EventInfo        `json:"events,omitempty"`         // MySQL only
+	EventTriggers   []EventTriggerInfo `json:"event_triggers,omitempty"` // PostgreSQL only
+	Variables       []Variable         `json:"variables,omitempty"`
+	Roles           []UserRole         `json:"roles,omitempty"`
+	Plugins         []Plugin           `json:"plugins,omitempty"`
+	Components      []Component        `json:"components,omitempty"`
+	ReplicationInfo *ReplicationInfo   `json:"replication,omitempty"`
+	Extensions      []Extension        `json:"extensions,omitempty"` // PostgreSQL only
+	Types           []TypeInfo         `json:"types,omitempty"`      // PostgreSQL only
+	Schemas         []SchemaInfo       `json:"schemas,omitempty"`    // PostgreSQL only
+	Privileges      []PrivilegeInfo    `json:"privileges,omitempty"` // PostgreSQL only
+	Workload        []StatementDigest  `json:"workload,omitempty"`   // collected with -workload
+	Lint            []Finding          `json:"lint,omitempty"`       // computed with -lint
+	Security        []Finding          `json:"security,omitempty"`   // computed with -security-audit
+	RoleGraph       *RoleGraph         `json:"role_graph,omitempty"`
+	Access          *AccessReport      `json:"access,omitempty"`    // computed with -effective-privileges
+	Upgrade         *UpgradeReport     `json:"upgrade,omitempty"`   // computed with -upgrade-check
+	Migration       *MigrationReport   `json:"migration,omitempty"` // computed with -migration-check
+}
+
+// RoleGraph holds the role memberships used to resolve effective privileges
+type RoleGraph struct {
+	Edges            []RoleEdge `json:"edges,omitempty"`
+	MandatoryRoles   []string   `json:"mandatory_roles,omitempty"`    // MySQL mandatory_roles, granted to every account
+	ActivateAllRoles bool       `json:"activate_all_roles,omitempty"` // MySQL activate_all_roles_on_login
+}
+
+// RoleEdge is a role granted to a user or to another role.
+// MySQL accounts are written as user@host.
+type RoleEdge struct {
+	Role    string `json:"role"`
+	Member  string `json:"member"`
+	Inherit bool   `json:"inherit"`           // the member uses the role's privileges without SET ROLE (PostgreSQL INHERIT option)
+	NoSet   bool   `json:"no_set,omitempty"`  // PostgreSQL 16+: granted WITH SET FALSE, so the member cannot SET ROLE to the role
+	Default bool   `json:"default,omitempty"` // MySQL: default role of the member, activated at login
+	Admin   bool   `json:"admin,omitempty"`   // WITH ADMIN OPTION
+}
+
+// AccessReport is the result of resolving privileges through the role graph
+type AccessReport struct {
+	Users   []UserAccess   `json:"users"`
+	Writers []TableWriters `json:"writers,omitempty"`
+}
+
+// UserAccess is the effective privileges of a login user
+type UserAccess struct {
+	User       string               `json:"user"`
+	Superuser  bool                 `json:"superuser,omitempty"`
+	Roles      []RoleAccess         `json:"roles,omitempty"`
+	Privileges []EffectivePrivilege `json:"privileges,omitempty"`
+}
+
+// RoleAccess is a role a user holds, directly or through other roles
+type RoleAccess struct {
+	Role    string   `json:"role"`
+	Path    []string `json:"path"`               // granted roles from the user down to Role
+	SetRole bool     `json:"set_role,omitempty"` // not active at login; needs SET ROLE
+}
+
+// EffectivePrivilege is a privilege a user holds, with the role it comes from
+type EffectivePrivilege struct {
+	Database   string `json:"database,omitempty"`
+	ObjectType string `json:"object_type"` // GLOBAL, DATABASE, SCHEMA, TABLE, ...
+	Object     string `json:"object"`
+	Column     string `json:"column,omitempty"`
+	Privilege  string `json:"privilege"`
+	Via        string `json:"via,omitempty"`      // role or PUBLIC; empty when granted to the user
+	SetRole    bool   `json:"set_role,omitempty"` // usable only after SET ROLE
+}
+
+// TableWriters lists the users that can modify the rows of a table
+type TableWriters struct {
+	Table   string        `json:"table"`
+	Writers []TableWriter `json:"writers"`
+}
+
+type TableWriter struct {
+	User       string   `json:"user"`
+	Privileges []string `json:"privileges"`         // INSERT, UPDATE, DELETE, TRUNCATE; ALL for superusers and owners
+	Via        string   `json:"via,omitempty"`      // role, PUBLIC, owner or superuser; empty for direct grants
+	SetRole    bool     `json:"set_role,omitempty"` // usable only after SET ROLE
+}
+
+// StatementDigest is a normalised statement with its aggregated execution statistics,
+// from performance_schema.events_statements_summary_by_digest (MySQL) or pg_stat_statements (PostgreSQL)
+type StatementDigest struct {
+	Database     string  `json:"database,omitempty"` // default schema (MySQL) or database (PostgreSQL)
+	User         string  `json:"user,omitempty"`     // PostgreSQL only
+	Digest       string  `json:"digest"`             // digest (MySQL) or queryid (PostgreSQL)
+	Query        string  `json:"query"`
+	Calls        int64   `json:"calls"`
+	TotalTimeMs  float64 `json:"total_time_ms"`
+	MeanTimeMs   float64 `json:"mean_time_ms"`
+	RowsExamined int64   `json:"rows_examined,omitempty"` // MySQL only
+	RowsReturned int64   `json:"rows_returned"`
+	BlocksHit    int64   `json:"blocks_hit,omitempty"`  // PostgreSQL shared buffer hits
+	BlocksRead   int64   `json:"blocks_read,omitempty"` // PostgreSQL shared blocks read
+}
+
+// TypeInfo represents a PostgreSQL user-defined type, domain or collation
+type TypeInfo struct {
+	Database    string   `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Schema      string   `json:"schema"`
+	Name        string   `json:"name"`
+	Kind        string   `json:"kind"` // ENUM, COMPOSITE, DOMAIN, RANGE, COLLATION
+	Owner       string   `json:"owner"`
+	Labels      []string `json:"labels,omitempty"`      // enum labels in sort order
+	Attributes  []string `json:"attributes,omitempty"`  // composite attributes as "name type"
+	BaseType    string   `json:"base_type,omitempty"`   // domain base type or range subtype
+	Constraints []string `json:"constraints,omitempty"` // domain constraints
+	Comment     string   `json:"comment"`
+	DDL         string   `json:"ddl"`
+}
+
+// SchemaInfo represents a PostgreSQL schema
+type SchemaInfo struct {
+	Database string `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	Comment  string `json:"comment,omitempty"`
 }
 
 // Extension represents a PostgreSQL extension
 type Extension struct {
-	Name        string
-	Version     string
-	Description string
+	Database       string `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	DefaultVersion string `json:"default_version,omitempty"` // version the installed packages provide
+	Description    string `json:"description"`
 }
 
 // Table information
 type TableInfo struct {
-	Database      string
-	Schema        string
-	Name          string
-	Type          string // TABLE, VIEW
-	Engine        string
-	AutoIncrement int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Collation     string
-	Charset       string
-	RowFormat     string
-	Comment       string
-	CreateOptions string
-	DDL           string
+	Database      string    `json:"database"`
+	Schema        string    `json:"schema"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"` // BASE TABLE, VIEW, MATERIALIZED VIEW, SEQUENCE, FOREIGN TABLE
+	Engine        string    `json:"engine"`
+	AutoIncrement int64     `json:"auto_increment"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Collation     string    `json:"collation"`
+	Charset       string    `json:"charset"`
+	RowFormat     string    `json:"row_format"`
+	Comment       string    `json:"comment"`
+	CreateOptions string    `json:"create_options"`
+	DDL           string    `json:"ddl"`
+
+	// PostgreSQL owner, who holds all privileges on the table implicitly
+	Owner    string `json:"owner,omitempty"`
+	Unlogged bool   `json:"unlogged,omitempty"` // PostgreSQL UNLOGGED table
+
+	// PostgreSQL declarative partitioning
+	PartitionKey   string `json:"partition_key,omitempty"`   // set on partitioned tables, e.g. RANGE (created_at)
+	PartitionOf    string `json:"partition_of,omitempty"`    // parent table (schema.name) of a partition
+	PartitionBound string `json:"partition_bound,omitempty"` // e.g. FOR VALUES FROM (...) TO (...)
+
+	// PostgreSQL row-level security
+	RowSecurity      bool         `json:"row_security,omitempty"`       // relrowsecurity
+	ForceRowSecurity bool         `json:"force_row_security,omitempty"` // relforcerowsecurity
+	Policies         []PolicyInfo `json:"policies,omitempty"`
+
+	// Structure read from the catalogs, so that consumers need not parse the DDL
+	Columns          []ColumnInfo          `json:"columns,omitempty"` // also set on views
+	Indexes          []IndexInfo           `json:"indexes,omitempty"` // includes the primary key
+	ForeignKeys      []ForeignKeyInfo      `json:"foreign_keys,omitempty"`
+	CheckConstraints []CheckConstraintInfo `json:"check_constraints,omitempty"`
+
+	Stats *TableStats `json:"stats,omitempty"` // collected with -stats
+}
+
+// ColumnInfo is a column of a table or view
+type ColumnInfo struct {
+	Name          string `json:"name"`
+	Position      int    `json:"position"`
+	Type          string `json:"type"` // with length and modifiers, e.g. varchar(50), int unsigned, numeric(10,2)
+	Nullable      bool   `json:"nullable"`
+	Default       string `json:"default,omitempty"`        // SQL expression as in the DDL, "" when there is none
+	Charset       string `json:"charset,omitempty"`        // MySQL
+	Collation     string `json:"collation,omitempty"`      // MySQL; PostgreSQL when not the type's default
+	AutoIncrement bool   `json:"auto_increment,omitempty"` // MySQL AUTO_INCREMENT
+	OnUpdate      string `json:"on_update,omitempty"`      // MySQL ON UPDATE, e.g. CURRENT_TIMESTAMP
+	Identity      string `json:"identity,omitempty"`       // PostgreSQL identity column: ALWAYS or BY DEFAULT
+	Generated     string `json:"generated,omitempty"`      // generated column expression
+	Comment       string `json:"comment,omitempty"`
+}
+
+// IndexInfo is an index of a table, including the primary key
+type IndexInfo struct {
+	Name      string   `json:"name"`
+	Columns   []string `json:"columns"` // key parts: column names, prefixes like name(10), or expressions
+	Primary   bool     `json:"primary,omitempty"`
+	Unique    bool     `json:"unique"`
+	Method    string   `json:"method,omitempty"`    // BTREE, HASH, FULLTEXT, SPATIAL, btree, gin, gist, ...
+	Predicate string   `json:"predicate,omitempty"` // WHERE clause of a PostgreSQL partial index
+}
+
+// ForeignKeyInfo is a foreign key constraint of a table
+type ForeignKeyInfo struct {
+	Name       string   `json:"name"`
+	Columns    []string `json:"columns"`
+	RefSchema  string   `json:"ref_schema"`
+	RefTable   string   `json:"ref_table"`
+	RefColumns []string `json:"ref_columns"`
+	OnUpdate   string   `json:"on_update"` // NO ACTION, RESTRICT, CASCADE, SET NULL, SET DEFAULT
+	OnDelete   string   `json:"on_delete"`
+}
+
+// CheckConstraintInfo is a CHECK constraint of a table (MySQL 8.0.16+, MariaDB 10.2+)
+type CheckConstraintInfo struct {
+	Name        string `json:"name"`
+	Expression  string `json:"expression"`
+	NotEnforced bool   `json:"not_enforced,omitempty"` // MySQL NOT ENFORCED
+}
+
+// TableStats holds size and planner statistics of a table.
+// Row counts are estimates maintained by the server, not exact counts.
+type TableStats struct {
+	RowEstimate int64             `json:"row_estimate"`
+	DataLength  int64             `json:"data_length"`  // bytes
+	IndexLength int64             `json:"index_length"` // bytes
+	DataFree    int64             `json:"data_free"`    // bytes allocated but unused
+	Indexes     []IndexStats      `json:"indexes,omitempty"`
+	Histograms  []ColumnHistogram `json:"histograms,omitempty"` // MySQL 8.0+
+
+	// PostgreSQL only (pg_stat_user_tables, counted since the last statistics reset)
+	ToastLength     int64  `json:"toast_length,omitempty"` // bytes, including the TOAST index
+	TotalLength     int64  `json:"total_length,omitempty"` // bytes, pg_total_relation_size
+	LiveTuples      int64  `json:"live_tuples,omitempty"`
+	DeadTuples      int64  `json:"dead_tuples,omitempty"`
+	SeqScans        int64  `json:"seq_scans,omitempty"`
+	IndexScans      int64  `json:"index_scans,omitempty"`
+	LastVacuum      string `json:"last_vacuum,omitempty"`
+	LastAutovacuum  string `json:"last_autovacuum,omitempty"`
+	LastAnalyze     string `json:"last_analyze,omitempty"`
+	LastAutoanalyze string `json:"last_autoanalyze,omitempty"`
+}
+
+// IndexStats holds statistics of one index
+type IndexStats struct {
+	Name        string   `json:"name"`
+	Columns     []string `json:"columns,omitempty"`
+	Unique      bool     `json:"unique"`
+	Cardinality int64    `json:"cardinality,omitempty"` // estimated number of distinct keys (MySQL)
+	SizePages   int64    `json:"size_pages,omitempty"`  // InnoDB persistent statistics
+	LeafPages   int64    `json:"leaf_pages,omitempty"`  // InnoDB persistent statistics
+
+	// PostgreSQL only (pg_stat_user_indexes)
+	Scans     int64 `json:"scans,omitempty"`
+	SizeBytes int64 `json:"size_bytes,omitempty"`
+	Unused    bool  `json:"unused,omitempty"` // never scanned and not enforcing a constraint
+}
+
+// ColumnHistogram describes a persistent histogram (ANALYZE TABLE ... UPDATE HISTOGRAM)
+type ColumnHistogram struct {
+	Column       string  `json:"column"`
+	Type         string  `json:"type"` // singleton or equi-height
+	Buckets      int     `json:"buckets"`
+	SamplingRate float64 `json:"sampling_rate"`
+	LastUpdated  string  `json:"last_updated"`
+}
+
+// PolicyInfo represents a PostgreSQL row-level security policy
+type PolicyInfo struct {
+	Name       string   `json:"name"`
+	Command    string   `json:"command"`    // ALL, SELECT, INSERT, UPDATE, DELETE
+	Permissive string   `json:"permissive"` // PERMISSIVE or RESTRICTIVE
+	Roles      []string `json:"roles,omitempty"`
+	Using      string   `json:"using"`
+	WithCheck  string   `json:"with_check"`
 }
 
 // User account information
 type UserAccount struct {
-	User            string
-	Host            string
-	SSLType         string
-	Plugin          string
-	AccountLocked   string
-	PasswordExpired string
-	Grants          []string
+	User            string   `json:"user"`
+	Host            string   `json:"host"`
+	SSLType         string   `json:"ssl_type"`
+	Plugin          string   `json:"plugin"`
+	AccountLocked   string   `json:"account_locked"`
+	PasswordExpired string   `json:"password_expired"`
+	EmptyPassword   bool     `json:"empty_password,omitempty"` // MySQL: empty authentication_string
+	Grants          []string `json:"grants,omitempty"`
 	// PostgreSQL specific
-	IsSuperuser   bool
-	CanCreateDB   bool
-	CanCreateRole bool
-	ConnLimit     int
-	ValidUntil    string
+	IsSuperuser   bool   `json:"is_superuser"`
+	CanCreateDB   bool   `json:"can_create_db"`
+	CanCreateRole bool   `json:"can_create_role"`
+	Replication   bool   `json:"replication,omitempty"`
+	BypassRLS     bool   `json:"bypass_rls,omitempty"`
+	ConnLimit     int    `json:"conn_limit"`
+	ValidUntil    string `json:"valid_until"`
 }
 
 // Stored routine information (procedures and functions)
 type RoutineInfo struct {
-	Schema       string
-	Name         string
-	Type         string // FUNCTION, PROCEDURE
-	Definer      string
-	Created      time.Time
-	LastAltered  time.Time
-	DataAccess   string
-	SecurityType string // DEFINER, INVOKER
-	Returns      string
-	Parameters   string
-	Definition   string
+	Database     string    `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Schema       string    `json:"schema"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"` // FUNCTION, PROCEDURE
+	Definer      string    `json:"definer"`
+	Created      time.Time `json:"created"`
+	LastAltered  time.Time `json:"last_altered"`
+	DataAccess   string    `json:"data_access"`
+	SecurityType string    `json:"security_type"` // DEFINER, INVOKER
+	Returns      string    `json:"returns"`
+	Parameters   string    `json:"parameters"`
+	Definition   string    `json:"definition"`
+	Comment      string    `json:"comment,omitempty"`
+	DDL          string    `json:"ddl,omitempty"` // MySQL: SHOW CREATE PROCEDURE/FUNCTION; the PostgreSQL definition is already a CREATE statement
+}
+
+// Trigger information
+type TriggerInfo struct {
+	Schema    string    `json:"schema"`
+	Name      string    `json:"name"`
+	Table     string    `json:"table"`
+	Timing    string    `json:"timing"` // BEFORE, AFTER
+	Event     string    `json:"event"`  // INSERT, UPDATE, DELETE
+	Definer   string    `json:"definer"`
+	SQLMode   string    `json:"sql_mode"`
+	Statement string    `json:"statement"`
+	Created   time.Time `json:"created"`
+	DDL       string    `json:"ddl"`
+}
+
+// Scheduled event information
+type EventInfo struct {
+	Schema       string    `json:"schema"`
+	Name         string    `json:"name"`
+	Definer      string    `json:"definer"`
+	TimeZone     string    `json:"time_zone"`
+	Type         string    `json:"type"`     // ONE TIME, RECURRING
+	Schedule     string    `json:"schedule"` // e.g. "EVERY 1 DAY STARTS ..." or "AT ..."
+	Status       string    `json:"status"`   // ENABLED, DISABLED, SLAVESIDE_DISABLED
+	OnCompletion string    `json:"on_completion"`
+	SQLMode      string    `json:"sql_mode"`
+	Body         string    `json:"body"`
+	Comment      string    `json:"comment"`
+	Created      time.Time `json:"created"`
+	LastAltered  time.Time `json:"last_altered"`
+	LastExecuted time.Time `json:"last_executed"`
+	DDL          string    `json:"ddl"`
+}
+
+// PostgreSQL event trigger information
+type EventTriggerInfo struct {
+	Database string   `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Name     string   `json:"name"`
+	Event    string   `json:"event"` // ddl_command_start, ddl_command_end, sql_drop, table_rewrite, login
+	Owner    string   `json:"owner"`
+	Enabled  string   `json:"enabled"` // ENABLED, DISABLED, ENABLE REPLICA, ENABLE ALWAYS
+	Tags     []string `json:"tags,omitempty"`
+	Function string   `json:"function"`
+	DDL      string   `json:"ddl"`
 }
 
 // Variable information
 type Variable struct {
-	Name         string
-	CurrentValue string
-	DefaultValue string
-	Source       string
-	IsModified   bool
+	Name         string `json:"name"`
+	CurrentValue string `json:"current_value"`
+	DefaultValue string `json:"default_value"`
+	Source       string `json:"source"`
+	IsModified   bool   `json:"is_modified"`
+}
+
+// PrivilegeInfo is a single privilege from a PostgreSQL ACL (one aclexplode() row)
+type PrivilegeInfo struct {
+	Database   string `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Grantee    string `json:"grantee"`            // role name or PUBLIC
+	ObjectType string `json:"object_type"`        // DATABASE, SCHEMA, TABLE, SEQUENCE, FUNCTION, PROCEDURE, TYPE, DOMAIN; DEFAULT <kind> for default privileges
+	Object     string `json:"object"`             // schema-qualified name; the schema (or empty) for default privileges
+	Column     string `json:"column,omitempty"`
+	Privilege  string `json:"privilege"` // SELECT, INSERT, USAGE, ...
+	Grantor    string `json:"grantor"`   // for default privileges, the role whose new objects get the privilege
+	Grantable  bool   `json:"grantable"`
 }
 
 // User role information
 type UserRole struct {
-	RoleName string
-	RoleHost string
-	Grants   []string
-	Members  []string
+	RoleName string   `json:"role_name"`
+	RoleHost string   `json:"role_host"`
+	Grants   []string `json:"grants,omitempty"`
+	Members  []string `json:"members,omitempty"`
+	// PostgreSQL role attributes, also listed in Grants for display
+	IsSuperuser   bool `json:"is_superuser,omitempty"`
+	CanCreateDB   bool `json:"can_create_db,omitempty"`
+	CanCreateRole bool `json:"can_create_role,omitempty"`
+	Replication   bool `json:"replication,omitempty"`
+	BypassRLS     bool `json:"bypass_rls,omitempty"`
 }
 
 // Plugin information
 type Plugin struct {
-	Name        string
-	Version     string
-	Status      string
-	Type        string
-	Library     string
-	Description string
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Status      string `json:"status"`
+	Type        string `json:"type"`
+	Library     string `json:"library"`
+	Description string `json:"description"`
 }
 
 // Component information (MySQL 8.0+)
 type Component struct {
-	ComponentID      int
-	ComponentGroupID int
-	ComponentURN     string
+	ComponentID      int    `json:"component_id"`
+	ComponentGroupID int    `json:"component_group_id"`
+	ComponentURN     string `json:"component_urn"`
 }
 
 // Replication information
 type ReplicationInfo struct {
-	ReplicationStatus    *ReplicationStatus
-	ReplicaStatus        []ReplicaStatus
-	SemiSyncStatus       *SemiSyncStatus
-	GroupReplicationInfo *GroupReplicationInfo
+	ReplicationStatus    *ReplicationStatus    `json:"replication_status,omitempty"`
+	ReplicaStatus        []ReplicaStatus       `json:"replica_status,omitempty"`
+	SemiSyncStatus       *SemiSyncStatus       `json:"semi_sync_status,omitempty"`
+	GroupReplicationInfo *GroupReplicationInfo `json:"group_replication,omitempty"`
+
+	// PostgreSQL
+	Role             string             `json:"role,omitempty"`     // primary or standby (pg_is_in_recovery)
+	Standbys         []StandbyStatus    `json:"standbys,omitempty"` // pg_stat_replication
+	ReplicationSlots []ReplicationSlot  `json:"replication_slots,omitempty"`
+	WALReceiver      *WALReceiverStatus `json:"wal_receiver,omitempty"`
+	Publications     []Publication      `json:"publications,omitempty"`
+	Subscriptions    []Subscription     `json:"subscriptions,omitempty"`
 }
 
 // Basic replication status
 type ReplicationStatus struct {
-	ServerID       int
-	ServerUUID     string
-	LogBinEnabled  bool
-	BinlogFormat   string
-	GTIDMode       string
-	CurrentLogFile string
-	CurrentLogPos  int64
+	ServerID       int    `json:"server_id"`
+	ServerUUID     string `json:"server_uuid"`
+	LogBinEnabled  bool   `json:"log_bin_enabled"`
+	BinlogFormat   string `json:"binlog_format"`
+	GTIDMode       string `json:"gtid_mode"`
+	CurrentLogFile string `json:"current_log_file"`
+	CurrentLogPos  int64  `json:"current_log_pos"`
 }
 
 // Replica status information
 type ReplicaStatus struct {
-	SlaveIORunning  string
-	SlaveSQLRunning string
-	MasterHost      string
-	MasterPort      int
-	MasterLogFile   string
-	MasterLogPos    int64
-	SecondsBehind   int64
+	SlaveIORunning  string `json:"slave_io_running"`
+	SlaveSQLRunning string `json:"slave_sql_running"`
+	MasterHost      string `json:"master_host"`
+	MasterPort      int    `json:"master_port"`
+	MasterLogFile   string `json:"master_log_file"`
+	MasterLogPos    int64  `json:"master_log_pos"`
+	SecondsBehind   int64  `json:"seconds_behind"`
 }
 
 // Semi-synchronous replication status
 type SemiSyncStatus struct {
-	MasterEnabled bool
-	SlaveEnabled  bool
+	MasterEnabled bool `json:"master_enabled"`
+	SlaveEnabled  bool `json:"slave_enabled"`
 }
 
 // Group replication information
 type GroupReplicationInfo struct {
-	GroupName         string
-	MemberState       string
-	SinglePrimaryMode bool
+	GroupName         string `json:"group_name"`
+	MemberState       string `json:"member_state"`
+	SinglePrimaryMode bool   `json:"single_primary_mode"`
+}
+
+// StandbyStatus is a PostgreSQL standby connected to this server (pg_stat_replication)
+type StandbyStatus struct {
+	ApplicationName string `json:"application_name"`
+	ClientAddr      string `json:"client_addr"`
+	State           string `json:"state"`      // startup, catchup, streaming, backup, stopping
+	SyncState       string `json:"sync_state"` // async, potential, sync, quorum
+	SentLSN         string `json:"sent_lsn"`
+	WriteLSN        string `json:"write_lsn"`
+	FlushLSN        string `json:"flush_lsn"`
+	ReplayLSN       string `json:"replay_lsn"`
+	WriteLag        string `json:"write_lag"`
+	FlushLag        string `json:"flush_lag"`
+	ReplayLag       string `json:"replay_lag"`
+}
+
+// ReplicationSlot is a PostgreSQL replication slot (pg_replication_slots)
+type ReplicationSlot struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`   // physical or logical
+	Plugin      string `json:"plugin"` // output plugin of a logical slot
+	Database    string `json:"database"`
+	Active      bool   `json:"active"`
+	WALStatus   string `json:"wal_status"`   // reserved, extended, unreserved, lost
+	RetainedWAL string `json:"retained_wal"` // WAL kept for the slot since restart_lsn
+}
+
+// WALReceiverStatus is the WAL receiver of a PostgreSQL standby (pg_stat_wal_receiver)
+type WALReceiverStatus struct {
+	Status             string `json:"status"`
+	SenderHost         string `json:"sender_host"`
+	SenderPort         int    `json:"sender_port"`
+	SlotName           string `json:"slot_name"`
+	FlushedLSN         string `json:"flushed_lsn"`
+	LatestEndLSN       string `json:"latest_end_lsn"`
+	LastMsgReceiptTime string `json:"last_msg_receipt_time"`
+}
+
+// Publication is a PostgreSQL logical replication publication
+type Publication struct {
+	Database   string   `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Name       string   `json:"name"`
+	Owner      string   `json:"owner"`
+	AllTables  bool     `json:"all_tables"`
+	Operations []string `json:"operations,omitempty"` // insert, update, delete, truncate
+	Tables     []string `json:"tables,omitempty"`
+	DDL        string   `json:"ddl"`
+}
+
+// Subscription is a PostgreSQL logical replication subscription
+type Subscription struct {
+	Database     string   `json:"database,omitempty"` // set when several PostgreSQL databases are collected
+	Name         string   `json:"name"`
+	Owner        string   `json:"owner"`
+	Enabled      bool     `json:"enabled"`
+	Publications []string `json:"publications,omitempty"`
+	SlotName     string   `json:"slot_name"`
+	DDL          string   `json:"ddl"` // the connection string is shown with the password removed
 }
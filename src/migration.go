@@ -0,0 +1,447 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The migration check reports how the tables, views and stored programs of a
+// MySQL server translate to PostgreSQL. Like the upgrade check it works on the
+// collected information, so it can run on a snapshot. Error findings need a
+// manual rewrite, warnings change behavior and info findings are converted
+// automatically but are worth a review.
+
+// MigrationReport is the result of -migration-check
+type MigrationReport struct {
+	Source       string            `json:"source"` // e.g. MySQL 8.0.36
+	Target       string            `json:"target"`
+	Objects      []MigrationObject `json:"objects"`
+	TypeMappings []TypeMapping     `json:"type_mappings"`
+	Findings     []Finding         `json:"findings"`
+}
+
+// MigrationObject is a table, view or stored program with the work its translation needs
+type MigrationObject struct {
+	Object string `json:"object"` // e.g. "table shop.orders", "procedure shop.refund"
+	Status string `json:"status"` // automatic, review (warnings) or manual (errors)
+}
+
+// TypeMapping is a MySQL column type and the PostgreSQL type it becomes
+type TypeMapping struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Columns int    `json:"columns"` // number of columns with this type
+}
+
+// CheckMigration checks the collected MySQL objects against target
+func CheckMigration(info *DatabaseInfo, target string) (*MigrationReport, error) {
+	if info.DBType != "mysql" {
+		return nil, fmt.Errorf("migration check supports MySQL sources only")
+	}
+	switch strings.ToLower(target) {
+	case "postgres", "postgresql":
+	default:
+		return nil, fmt.Errorf("unsupported migration target '%s'. Valid values: postgres", target)
+	}
+
+	report := &MigrationReport{Source: "MySQL", Target: "PostgreSQL", Findings: []Finding{}}
+	if info.ConnectionInfo != nil && info.ConnectionInfo.Version != "" {
+		report.Source += " " + info.ConnectionInfo.Version
+	}
+	add := func(severity Severity, rule, object, message string) {
+		report.Findings = append(report.Findings, Finding{Severity: severity, Rule: rule, Object: object, Message: message})
+	}
+
+	var objects []string
+	mappings := make(map[[2]string]int)
+	for _, table := range baseTables(info) {
+		object := "table " + table.name()
+		objects = append(objects, object)
+		for _, column := range table.structure.Columns {
+			mappings[[2]string{mysqlTypeLabel(column.Type), postgresType(column)}]++
+		}
+		checkMigrationColumns(table, object, add)
+		checkMigrationIndexes(table, object, add)
+	}
+	for _, stored := range storedSQLObjects(info) {
+		objects = append(objects, stored.object)
+		checkMigrationSQL(stored, add)
+	}
+
+	// An object needs the work of its most severe finding
+	worst := make(map[string]Severity)
+	for _, finding := range report.Findings {
+		if finding.Severity.rank() > worst[finding.Object].rank() {
+			worst[finding.Object] = finding.Severity
+		}
+	}
+	for _, object := range objects {
+		status := "automatic"
+		switch worst[object] {
+		case SeverityError:
+			status = "manual"
+		case SeverityWarning:
+			status = "review"
+		}
+		report.Objects = append(report.Objects, MigrationObject{Object: object, Status: status})
+	}
+
+	for mapping, columns := range mappings {
+		report.TypeMappings = append(report.TypeMappings, TypeMapping{From: mapping[0], To: mapping[1], Columns: columns})
+	}
+	sort.Slice(report.TypeMappings, func(i, j int) bool {
+		a, b := report.TypeMappings[i], report.TypeMappings[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	sortFindings(report.Findings)
+	return report, nil
+}
+
+// migrationSummary counts the objects per status, e.g. "10 automatic, 2 review, 1 manual"
+func migrationSummary(objects []MigrationObject) string {
+	counts := make(map[string]int)
+	for _, object := range objects {
+		counts[object.Status]++
+	}
+	return fmt.Sprintf("%d automatic, %d review, %d manual", counts["automatic"], counts["review"], counts["manual"])
+}
+
+// splitMySQLType splits a column type such as "decimal(10,2) unsigned zerofill"
+// into its name, the content of its parentheses and whether it is unsigned
+func splitMySQLType(columnType string) (name, args string, unsigned bool) {
+	name = columnType
+	rest := ""
+	if i := strings.IndexAny(columnType, "( "); i >= 0 {
+		name, rest = columnType[:i], columnType[i:]
+	}
+	if strings.HasPrefix(rest, "(") {
+		if end := matchingParen(rest, 0); end > 0 {
+			args, rest = rest[1:end], rest[end+1:]
+		}
+	}
+	return name, args, strings.Contains(rest, "unsigned")
+}
+
+// mysqlTypeLabel is the column type shown in the type mappings; enum and set values are left out
+func mysqlTypeLabel(columnType string) string {
+	name, _, _ := splitMySQLType(columnType)
+	if name == "enum" || name == "set" {
+		return name + "(...)"
+	}
+	return columnType
+}
+
+// mysqlSpatialTypes need PostGIS on PostgreSQL
+var mysqlSpatialTypes = map[string]bool{
+	"geometry":           true,
+	"point":              true,
+	"linestring":         true,
+	"polygon":            true,
+	"multipoint":         true,
+	"multilinestring":    true,
+	"multipolygon":       true,
+	"geometrycollection": true,
+	"geomcollection":     true,
+}
+
+// postgresType returns the PostgreSQL type a MySQL column translates to.
+// Unsigned integers get the next larger signed type so that all values fit.
+func postgresType(column ddlColumn) string {
+	name, args, unsigned := splitMySQLType(column.Type)
+	precision := ""
+	if args != "" {
+		precision = "(" + args + ")"
+	}
+	switch name {
+	case "tinyint":
+		if args == "1" {
+			return "boolean"
+		}
+		return "smallint"
+	case "smallint":
+		if unsigned {
+			return "integer"
+		}
+		return "smallint"
+	case "mediumint":
+		return "integer"
+	case "int", "integer":
+		if unsigned {
+			return "bigint"
+		}
+		return "integer"
+	case "bigint":
+		if unsigned {
+			return "numeric(20)"
+		}
+		return "bigint"
+	case "decimal", "numeric", "dec", "fixed":
+		return "numeric" + precision
+	case "float":
+		// float(p) with p > 24 is double precision; float(m,d) is single precision
+		var p int
+		if _, err := fmt.Sscanf(args, "%d", &p); err == nil && !strings.Contains(args, ",") && p > 24 {
+			return "double precision"
+		}
+		return "real"
+	case "double", "real":
+		return "double precision"
+	case "bit":
+		return "bit" + precision
+	case "char", "varchar":
+		return name + precision
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return "bytea"
+	case "tinytext", "text", "mediumtext", "longtext", "enum":
+		return "text"
+	case "set":
+		return "text[]"
+	case "date":
+		return "date"
+	case "datetime":
+		return "timestamp" + precision
+	case "timestamp":
+		return "timestamptz" + precision
+	case "time":
+		return "time" + precision
+	case "year":
+		return "smallint"
+	case "json":
+		return "jsonb"
+	}
+	if mysqlSpatialTypes[name] {
+		return "geometry"
+	}
+	return column.Type
+}
+
+// isCharacterType reports whether a MySQL column type has a collation
+func isCharacterType(columnType string) bool {
+	switch name, _, _ := splitMySQLType(columnType); name {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext", "enum", "set":
+		return true
+	}
+	return false
+}
+
+// checkMigrationColumns reports the column types and attributes that change meaning or need extra work
+func checkMigrationColumns(table lintTable, object string, add addFinding) {
+	var enums, sets, booleans, timestamps, onUpdate, zeroDates, spatial, caseInsensitive []string
+	var unsigned []string
+	unsignedSeverity := SeverityInfo
+	collations := make(map[string]bool)
+
+	for _, column := range table.structure.Columns {
+		name, args, isUnsigned := splitMySQLType(column.Type)
+		switch {
+		case name == "enum":
+			enums = append(enums, column.Name)
+		case name == "set":
+			sets = append(sets, column.Name)
+		case name == "tinyint" && args == "1":
+			booleans = append(booleans, column.Name)
+		case name == "timestamp":
+			timestamps = append(timestamps, column.Name)
+		case mysqlSpatialTypes[name]:
+			spatial = append(spatial, column.Name)
+		}
+		if isUnsigned && !(name == "tinyint" && args == "1") {
+			to := postgresType(column)
+			if name == "bigint" {
+				unsignedSeverity = SeverityWarning
+			}
+			unsigned = append(unsigned, fmt.Sprintf("%s (%s)", column.Name, to))
+		}
+		if column.OnUpdate != "" {
+			onUpdate = append(onUpdate, column.Name)
+		}
+		if zeroDateRe.MatchString(column.Default) {
+			zeroDates = append(zeroDates, column.Name)
+		}
+		if column.AutoIncrement {
+			message := fmt.Sprintf("AUTO_INCREMENT column %s becomes GENERATED BY DEFAULT AS IDENTITY", column.Name)
+			if next := table.info.AutoIncrement; next > 0 {
+				message += fmt.Sprintf("; restart it at %d after loading the data (ALTER TABLE ... ALTER COLUMN %s RESTART WITH %d)", next, column.Name, next)
+			}
+			add(SeverityInfo, "auto-increment", object, message)
+		}
+		if isCharacterType(column.Type) {
+			collation := firstNonEmpty(column.Collation, table.structure.Collation, table.info.Collation)
+			if strings.HasSuffix(strings.ToLower(collation), "_ci") {
+				caseInsensitive = append(caseInsensitive, column.Name)
+				collations[collation] = true
+			}
+		}
+	}
+
+	if len(enums) > 0 {
+		add(SeverityInfo, "enum", object, fmt.Sprintf("enum columns %s become text with a CHECK constraint on the allowed values (or a CREATE TYPE ... AS ENUM); MySQL sorts enums by position, not by label",
+			strings.Join(enums, ", ")))
+	}
+	if len(sets) > 0 {
+		add(SeverityWarning, "set-type", object, fmt.Sprintf("set columns %s become text[]; FIND_IN_SET() and bit arithmetic on them need rewriting",
+			strings.Join(sets, ", ")))
+	}
+	if len(booleans) > 0 {
+		add(SeverityWarning, "tinyint-boolean", object, fmt.Sprintf("tinyint(1) columns %s become boolean; comparisons with 0 and 1 need true and false, and values other than 0 and 1 do not fit",
+			strings.Join(booleans, ", ")))
+	}
+	if len(unsigned) > 0 {
+		message := fmt.Sprintf("unsigned columns %s become the next larger signed type; add CHECK (column >= 0) to keep rejecting negative values",
+			strings.Join(unsigned, ", "))
+		if unsignedSeverity == SeverityWarning {
+			message += "; numeric(20) is slower than bigint, keep bigint if the values stay below 2^63"
+		}
+		add(unsignedSeverity, "unsigned", object, message)
+	}
+	if len(timestamps) > 0 {
+		add(SeverityInfo, "timestamp", object, fmt.Sprintf("timestamp columns %s become timestamptz, which like MySQL TIMESTAMP stores UTC and converts to the session time zone; datetime columns become timestamp without time zone",
+			strings.Join(timestamps, ", ")))
+	}
+	if len(onUpdate) > 0 {
+		add(SeverityWarning, "on-update-timestamp", object, fmt.Sprintf("columns %s use ON UPDATE CURRENT_TIMESTAMP, which needs a BEFORE UPDATE trigger on PostgreSQL",
+			strings.Join(onUpdate, ", ")))
+	}
+	if len(zeroDates) > 0 {
+		add(SeverityError, "zero-date", object, fmt.Sprintf("columns %s default to a zero date, which PostgreSQL rejects; zero dates in the data must become NULL as well",
+			strings.Join(zeroDates, ", ")))
+	}
+	if len(spatial) > 0 {
+		add(SeverityWarning, "spatial-type", object, fmt.Sprintf("spatial columns %s need the PostGIS extension (geometry)",
+			strings.Join(spatial, ", ")))
+	}
+	if len(caseInsensitive) > 0 {
+		var names []string
+		for collation := range collations {
+			names = append(names, collation)
+		}
+		sort.Strings(names)
+		add(SeverityWarning, "collation", object, fmt.Sprintf("columns %s compare case-insensitively (%s) while PostgreSQL collations are case-sensitive, which changes unique keys, lookups and GROUP BY; use citext or a nondeterministic ICU collation where it matters",
+			strings.Join(caseInsensitive, ", "), strings.Join(names, ", ")))
+	}
+}
+
+// mysqlPrefixKeyRe matches a key of SHOW CREATE TABLE with a column prefix such as `name`(10)
+var mysqlPrefixKeyRe = regexp.MustCompile("(?m)^\\s*(PRIMARY |UNIQUE )?KEY (`(?:[^`]|``)*` )?\\(.*`\\(\\d+\\)")
+
+// checkMigrationIndexes reports the index types and key parts PostgreSQL does not have
+func checkMigrationIndexes(table lintTable, object string, add addFinding) {
+	for _, index := range table.structure.Indexes {
+		switch index.Method {
+		case "FULLTEXT":
+			add(SeverityWarning, "index-type", object, fmt.Sprintf("FULLTEXT index %s becomes a GIN index on to_tsvector(...); MATCH ... AGAINST queries must be rewritten with @@ and rank differently",
+				index.Name))
+		case "SPATIAL":
+			add(SeverityWarning, "index-type", object, fmt.Sprintf("SPATIAL index %s becomes a GiST index on a PostGIS geometry", index.Name))
+		}
+	}
+	for _, m := range mysqlPrefixKeyRe.FindAllStringSubmatch(table.info.DDL, -1) {
+		name := unquoteIdent(strings.TrimSpace(m[2]))
+		if m[1] == "PRIMARY " {
+			name = "PRIMARY"
+		}
+		severity := SeverityWarning
+		message := fmt.Sprintf("index %s has column prefix key parts, which PostgreSQL does not support; index the whole column or an expression such as left(column, n)", name)
+		if m[1] != "" {
+			// A unique prefix constrains only the prefix, which an index on the whole column does not reproduce
+			severity = SeverityError
+			message += "; the uniqueness of the prefix needs a unique expression index"
+		}
+		add(severity, "prefix-index", object, message)
+	}
+}
+
+// mysqlOnlySyntax is MySQL-specific SQL with its PostgreSQL replacement
+var mysqlOnlySyntax = []struct {
+	re          *regexp.Regexp
+	syntax      string
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\bIFNULL\s*\(`), "IFNULL()", "COALESCE()"},
+	{regexp.MustCompile(`(?i)\bIF\(`), "IF()", "CASE WHEN"},
+	{regexp.MustCompile(`(?i)\bGROUP_CONCAT\s*\(`), "GROUP_CONCAT()", "string_agg()"},
+	{regexp.MustCompile(`(?i)\bDATE_FORMAT\s*\(`), "DATE_FORMAT()", "to_char()"},
+	{regexp.MustCompile(`(?i)\bSTR_TO_DATE\s*\(`), "STR_TO_DATE()", "to_date() or to_timestamp()"},
+	{regexp.MustCompile(`(?i)\bDATE_(ADD|SUB)\s*\(`), "DATE_ADD()/DATE_SUB()", "+/- interval"},
+	{regexp.MustCompile(`(?i)\bUNIX_TIMESTAMP\s*\(`), "UNIX_TIMESTAMP()", "extract(epoch from ...)"},
+	{regexp.MustCompile(`(?i)\bFROM_UNIXTIME\s*\(`), "FROM_UNIXTIME()", "to_timestamp()"},
+	{regexp.MustCompile(`(?i)\bCURDATE\s*\(`), "CURDATE()", "CURRENT_DATE"},
+	{regexp.MustCompile(`(?i)\bLAST_INSERT_ID\s*\(`), "LAST_INSERT_ID()", "INSERT ... RETURNING"},
+	{regexp.MustCompile(`(?i)\bFIND_IN_SET\s*\(`), "FIND_IN_SET()", "= ANY(string_to_array())"},
+	{regexp.MustCompile(`(?i)\bLIMIT\s+\d+\s*,`), "LIMIT offset, count", "LIMIT count OFFSET offset"},
+	{regexp.MustCompile(`(?i)\bSTRAIGHT_JOIN\b|\b(USE|FORCE|IGNORE)\s+INDEX\b`), "join order and index hints", "no equivalent"},
+	{regexp.MustCompile(`(?i)\bON\s+DUPLICATE\s+KEY\s+UPDATE\b`), "ON DUPLICATE KEY UPDATE", "ON CONFLICT ... DO UPDATE"},
+	{regexp.MustCompile(`(?i)\bREPLACE\s+INTO\b`), "REPLACE INTO", "INSERT ... ON CONFLICT ... DO UPDATE"},
+	{regexp.MustCompile(`(?i)\bINSERT\s+IGNORE\b`), "INSERT IGNORE", "ON CONFLICT DO NOTHING"},
+}
+
+// mysqlStoredProgramSyntax is SQL/PSM that PL/pgSQL writes differently
+var mysqlStoredProgramSyntax = []struct {
+	re          *regexp.Regexp
+	syntax      string
+	replacement string
+}{
+	{regexp.MustCompile(`(?is)\bDECLARE\b[^;]*\bHANDLER\s+FOR\b`), "DECLARE ... HANDLER", "EXCEPTION block"},
+	{regexp.MustCompile(`(?i)\b(RE)?SIGNAL\s+SQLSTATE\b`), "SIGNAL", "RAISE EXCEPTION"},
+	{regexp.MustCompile(`(?i)\b(LEAVE|ITERATE)\s+\w+\s*;`), "LEAVE/ITERATE", "EXIT/CONTINUE"},
+	{regexp.MustCompile(`(?i)\bUNTIL\b`), "REPEAT ... UNTIL", "LOOP ... EXIT WHEN"},
+	{regexp.MustCompile(`(?i)\bPREPARE\s+\w+\s+FROM\b`), "PREPARE/EXECUTE", "EXECUTE format()"},
+	{regexp.MustCompile("(?:^|[^\\w@`'\"])@[A-Za-z_]"), "user variables", "PL/pgSQL variables"},
+}
+
+// viewQueryRe matches the query of a CREATE VIEW statement, after the view name
+var viewQueryRe = regexp.MustCompile(`(?is)\bVIEW\s+\S+\s+AS\s+(.*)$`)
+
+// viewQuery returns the query of a view's DDL, without the definer and security clauses of SHOW CREATE VIEW
+func viewQuery(ddl string) string {
+	if m := viewQueryRe.FindStringSubmatch(ddl); m != nil {
+		return m[1]
+	}
+	return ddl
+}
+
+// checkMigrationSQL reports the MySQL-specific syntax of a view or stored program
+func checkMigrationSQL(stored storedSQL, add addFinding) {
+	kind, _, _ := strings.Cut(stored.object, " ")
+	text := stored.text
+	if kind == "view" {
+		text = viewQuery(text)
+	}
+
+	var uses []string
+	for _, syntax := range mysqlOnlySyntax {
+		if syntax.re.MatchString(text) {
+			uses = append(uses, syntax.syntax+" → "+syntax.replacement)
+		}
+	}
+	if kind != "view" {
+		for _, syntax := range mysqlStoredProgramSyntax {
+			if syntax.re.MatchString(text) {
+				uses = append(uses, syntax.syntax+" → "+syntax.replacement)
+			}
+		}
+	}
+	detail := ""
+	if len(uses) > 0 {
+		detail = "; uses " + strings.Join(uses, ", ")
+	}
+
+	switch kind {
+	case "view":
+		if len(uses) > 0 {
+			add(SeverityWarning, "view-syntax", stored.object, "query uses MySQL-specific syntax: "+strings.Join(uses, ", "))
+		}
+	case "procedure", "function":
+		add(SeverityError, "stored-program", stored.object, "stored programs must be rewritten in PL/pgSQL"+detail)
+	case "trigger":
+		add(SeverityWarning, "trigger", stored.object, "becomes a PL/pgSQL trigger function and a CREATE TRIGGER ... FOR EACH ROW"+detail)
+	case "event":
+		add(SeverityError, "event", stored.object, "PostgreSQL has no event scheduler; run the body with pg_cron or an external scheduler"+detail)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"database/sql"
 	"fmt"
+	"log"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -50,6 +52,21 @@ func (c *MySQLCollector) CollectAll() (*DatabaseInfo, error) {
 		}
 	}
 
+	// Collect columns, indexes, foreign keys and check constraints of the collected tables
+	if !c.config.ExceptTables {
+		if err := c.collectTableStructure(info); err != nil {
+			log.Printf("Warning: failed to collect table structure: %v", err)
+			dropTableStructure(info.Tables)
+		}
+	}
+
+	// Collect table and index statistics if requested
+	if c.config.Stats && !c.config.ExceptTables {
+		if err := c.collectTableStats(info); err != nil {
+			return nil, fmt.Errorf("failed to collect table statistics: %v", err)
+		}
+	}
+
 	// Collect users unless excluded
 	if !c.config.ExceptUsers {
 		if err := c.collectUsers(info); err != nil {
@@ -71,6 +88,20 @@ func (c *MySQLCollector) CollectAll() (*DatabaseInfo, error) {
 		}
 	}
 
+	// Collect triggers unless excluded
+	if !c.config.ExceptTriggers {
+		if err := c.collectTriggers(info); err != nil {
+			return nil, fmt.Errorf("failed to collect triggers: %v", err)
+		}
+	}
+
+	// Collect scheduled events unless excluded
+	if !c.config.ExceptEvents {
+		if err := c.collectEvents(info); err != nil {
+			return nil, fmt.Errorf("failed to collect events: %v", err)
+		}
+	}
+
 	// Collect variables unless excluded
 	if !c.config.ExceptVariables {
 		if err := c.collectVariables(info); err != nil {
@@ -98,6 +129,14 @@ func (c *MySQLCollector) CollectAll() (*DatabaseInfo, error) {
 		}
 	}
 
+	// Collect the top statement digests if requested. performance_schema can be
+	// disabled, so as with PostgreSQL's pg_stat_statements this is not fatal.
+	if c.config.Workload {
+		if err := c.collectWorkload(info); err != nil {
+			log.Printf("Warning: failed to collect workload: %v", err)
+		}
+	}
+
 	return info, nil
 }
 
@@ -229,7 +268,8 @@ func (c *MySQLCollector) getTablesForDatabase(dbName string) ([]TableInfo, error
 		if rowFormat.Valid {
 			table.RowFormat = rowFormat.String
 		}
-		if comment.Valid {
+		// TABLE_COMMENT of a view is the word VIEW; MySQL has no view comments
+		if comment.Valid && table.Type != "VIEW" {
 			table.Comment = comment.String
 		}
 		if createOptions.Valid {
@@ -271,17 +311,437 @@ func (c *MySQLCollector) getTableDDL(schema, name, tableType string) (string, er
 	}
 }
 
+// collectTableStructure attaches the columns, indexes, foreign keys and check constraints
+// of the collected tables and views from information_schema
+func (c *MySQLCollector) collectTableStructure(info *DatabaseInfo) error {
+	tables := make(map[string]*TableInfo)
+	var databases []string
+	for i := range info.Tables {
+		table := &info.Tables[i]
+		if len(databases) == 0 || databases[len(databases)-1] != table.Schema {
+			databases = append(databases, table.Schema)
+		}
+		tables[table.Schema+"."+table.Name] = table
+	}
+
+	for _, dbName := range databases {
+		if err := c.getColumns(dbName, tables); err != nil {
+			return err
+		}
+		if err := c.getIndexes(dbName, tables); err != nil {
+			return err
+		}
+		if err := c.getForeignKeys(dbName, tables); err != nil {
+			return err
+		}
+		// CHECK constraints are enforced from MySQL 8.0.16 and MariaDB 10.2
+		if (c.version.IsMariaDB() && c.version.IsAtLeast(10, 2, 1)) || (!c.version.IsMariaDB() && c.version.IsAtLeast(8, 0, 16)) {
+			if err := c.getCheckConstraints(dbName, tables); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mysqlOnUpdateRe matches the ON UPDATE clause in information_schema.COLUMNS.EXTRA
+var mysqlOnUpdateRe = regexp.MustCompile(`(?i)\bon update (\S+)`)
+
+// getColumns reads the columns of the tables and views from information_schema.COLUMNS
+func (c *MySQLCollector) getColumns(dbName string, tables map[string]*TableInfo) error {
+	// Generated columns exist from MySQL 5.7.6 and MariaDB 10.2.5
+	generation := "''"
+	if (c.version.IsMariaDB() && c.version.IsAtLeast(10, 2, 5)) || (!c.version.IsMariaDB() && c.version.IsAtLeast(5, 7, 6)) {
+		generation = "COALESCE(GENERATION_EXPRESSION, '')"
+	}
+	query := fmt.Sprintf(`
+		SELECT TABLE_NAME, COLUMN_NAME, ORDINAL_POSITION, COLUMN_TYPE, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT,
+		       COALESCE(CHARACTER_SET_NAME, ''), COALESCE(COLLATION_NAME, ''), EXTRA, COLUMN_COMMENT, %s
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME, ORDINAL_POSITION`, generation)
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, dataType, isNullable, extra, generated string
+		var columnDefault sql.NullString
+		var column ColumnInfo
+		if err := rows.Scan(&tableName, &column.Name, &column.Position, &column.Type, &dataType, &isNullable,
+			&columnDefault, &column.Charset, &column.Collation, &extra, &column.Comment, &generated); err != nil {
+			continue
+		}
+		table, ok := tables[dbName+"."+tableName]
+		if !ok {
+			continue
+		}
+
+		column.Nullable = isNullable == "YES"
+		column.AutoIncrement = strings.Contains(strings.ToLower(extra), "auto_increment")
+		if m := mysqlOnUpdateRe.FindStringSubmatch(extra); m != nil {
+			column.OnUpdate = m[1]
+		}
+		if generated != "" {
+			column.Generated = generated
+		} else {
+			column.Default = c.columnDefault(columnDefault, dataType, extra)
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	return rows.Err()
+}
+
+// columnDefault renders COLUMN_DEFAULT as in the DEFAULT clause of SHOW CREATE TABLE:
+// literals are quoted and expressions are kept as they are
+func (c *MySQLCollector) columnDefault(value sql.NullString, dataType, extra string) string {
+	if !value.Valid {
+		return ""
+	}
+	// MariaDB 10.2.7+ reports the default as an SQL expression already
+	if c.version.IsMariaDB() {
+		if value.String == "NULL" {
+			return ""
+		}
+		return value.String
+	}
+	switch {
+	case currentTimestampRe.MatchString(value.String), dataType == "bit":
+		return value.String
+	case strings.Contains(extra, "DEFAULT_GENERATED"):
+		// Expression defaults (8.0.13+)
+		return "(" + value.String + ")"
+	}
+	return quoteLiteral(value.String)
+}
+
+// getIndexes reads the indexes of the tables from information_schema.STATISTICS
+func (c *MySQLCollector) getIndexes(dbName string, tables map[string]*TableInfo) error {
+	// Functional key parts exist from MySQL 8.0.13
+	expression := "''"
+	if !c.version.IsMariaDB() && c.version.IsAtLeast(8, 0, 13) {
+		expression = "COALESCE(EXPRESSION, '')"
+	}
+	query := fmt.Sprintf(`
+		SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COALESCE(COLUMN_NAME, ''), COALESCE(SUB_PART, 0),
+		       COALESCE(COLLATION, ''), INDEX_TYPE, %s
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME, INDEX_NAME = 'PRIMARY' DESC, INDEX_NAME, SEQ_IN_INDEX`, expression)
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, indexName, column, collation, method, expr string
+		var nonUnique, subPart int
+		if err := rows.Scan(&tableName, &indexName, &nonUnique, &column, &subPart, &collation, &method, &expr); err != nil {
+			continue
+		}
+		table, ok := tables[dbName+"."+tableName]
+		if !ok {
+			continue
+		}
+
+		n := len(table.Indexes)
+		if n == 0 || table.Indexes[n-1].Name != indexName {
+			table.Indexes = append(table.Indexes, IndexInfo{
+				Name:    indexName,
+				Primary: indexName == "PRIMARY",
+				Unique:  nonUnique == 0,
+				Method:  method,
+			})
+			n++
+		}
+		part := column
+		switch {
+		case column == "":
+			part = "(" + expr + ")"
+		case subPart > 0:
+			part = fmt.Sprintf("%s(%d)", column, subPart)
+		}
+		if collation == "D" {
+			part += " DESC"
+		}
+		table.Indexes[n-1].Columns = append(table.Indexes[n-1].Columns, part)
+	}
+	return rows.Err()
+}
+
+// getForeignKeys reads the foreign keys of the tables from information_schema.KEY_COLUMN_USAGE
+// and REFERENTIAL_CONSTRAINTS
+func (c *MySQLCollector) getForeignKeys(dbName string, tables map[string]*TableInfo) error {
+	query := `
+		SELECT k.TABLE_NAME, k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_SCHEMA,
+		       k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME, r.UPDATE_RULE, r.DELETE_RULE
+		FROM information_schema.KEY_COLUMN_USAGE k
+		JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+		  ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.TABLE_NAME = k.TABLE_NAME
+		 AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+		WHERE k.TABLE_SCHEMA = ? AND k.REFERENCED_TABLE_NAME IS NOT NULL
+		ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, column, refColumn string
+		var fk ForeignKeyInfo
+		if err := rows.Scan(&tableName, &fk.Name, &column, &fk.RefSchema, &fk.RefTable, &refColumn,
+			&fk.OnUpdate, &fk.OnDelete); err != nil {
+			continue
+		}
+		table, ok := tables[dbName+"."+tableName]
+		if !ok {
+			continue
+		}
+
+		n := len(table.ForeignKeys)
+		if n == 0 || table.ForeignKeys[n-1].Name != fk.Name {
+			table.ForeignKeys = append(table.ForeignKeys, fk)
+			n++
+		}
+		table.ForeignKeys[n-1].Columns = append(table.ForeignKeys[n-1].Columns, column)
+		table.ForeignKeys[n-1].RefColumns = append(table.ForeignKeys[n-1].RefColumns, refColumn)
+	}
+	return rows.Err()
+}
+
+// getCheckConstraints reads the check constraints of the tables from information_schema.CHECK_CONSTRAINTS
+func (c *MySQLCollector) getCheckConstraints(dbName string, tables map[string]*TableInfo) error {
+	// MySQL has the table name and the ENFORCED flag in TABLE_CONSTRAINTS only
+	query := `
+		SELECT t.TABLE_NAME, cc.CONSTRAINT_NAME, cc.CHECK_CLAUSE, t.ENFORCED
+		FROM information_schema.CHECK_CONSTRAINTS cc
+		JOIN information_schema.TABLE_CONSTRAINTS t
+		  ON t.CONSTRAINT_SCHEMA = cc.CONSTRAINT_SCHEMA AND t.CONSTRAINT_NAME = cc.CONSTRAINT_NAME
+		 AND t.CONSTRAINT_TYPE = 'CHECK'
+		WHERE cc.CONSTRAINT_SCHEMA = ?
+		ORDER BY t.TABLE_NAME, cc.CONSTRAINT_NAME`
+	if c.version.IsMariaDB() {
+		query = `
+		SELECT TABLE_NAME, CONSTRAINT_NAME, CHECK_CLAUSE, 'YES'
+		FROM information_schema.CHECK_CONSTRAINTS
+		WHERE CONSTRAINT_SCHEMA = ?
+		ORDER BY TABLE_NAME, CONSTRAINT_NAME`
+	}
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, enforced string
+		var check CheckConstraintInfo
+		if err := rows.Scan(&tableName, &check.Name, &check.Expression, &enforced); err != nil {
+			continue
+		}
+		if table, ok := tables[dbName+"."+tableName]; ok {
+			check.NotEnforced = enforced == "NO"
+			table.CheckConstraints = append(table.CheckConstraints, check)
+		}
+	}
+	return rows.Err()
+}
+
+// collectTableStats attaches size, index and histogram statistics to the collected tables.
+// information_schema.TABLES values are cached on 8.0+ (information_schema_stats_expiry),
+// so they may lag behind the live tables until ANALYZE TABLE is run.
+func (c *MySQLCollector) collectTableStats(info *DatabaseInfo) error {
+	tables := make(map[string]*TableInfo)
+	for i := range info.Tables {
+		if info.Tables[i].Type != "BASE TABLE" {
+			continue
+		}
+		tables[info.Tables[i].Schema+"."+info.Tables[i].Name] = &info.Tables[i]
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	databases, err := c.getDatabases()
+	if err != nil {
+		return err
+	}
+
+	for _, dbName := range databases {
+		if err := c.getTableSizes(dbName, tables); err != nil {
+			return err
+		}
+		if err := c.getIndexStats(dbName, tables); err != nil {
+			return err
+		}
+		// Persistent statistics and histograms are optional: mysql.innodb_index_stats
+		// needs SELECT on the mysql schema and COLUMN_STATISTICS only exists on 8.0+
+		c.getInnoDBIndexStats(dbName, tables)
+		if c.version.IsMySQL8OrLater() {
+			c.getHistograms(dbName, tables)
+		}
+	}
+	return nil
+}
+
+// getTableSizes reads row estimates and sizes from information_schema.TABLES
+func (c *MySQLCollector) getTableSizes(dbName string, tables map[string]*TableInfo) error {
+	query := `
+		SELECT TABLE_NAME, COALESCE(TABLE_ROWS, 0), COALESCE(DATA_LENGTH, 0),
+		       COALESCE(INDEX_LENGTH, 0), COALESCE(DATA_FREE, 0)
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'`
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var stats TableStats
+		if err := rows.Scan(&name, &stats.RowEstimate, &stats.DataLength, &stats.IndexLength, &stats.DataFree); err != nil {
+			continue
+		}
+		if table, ok := tables[dbName+"."+name]; ok {
+			table.Stats = &stats
+		}
+	}
+	return rows.Err()
+}
+
+// getIndexStats reads per-index cardinality from information_schema.STATISTICS.
+// The cardinality of an index is the one reported for its last column.
+func (c *MySQLCollector) getIndexStats(dbName string, tables map[string]*TableInfo) error {
+	query := `
+		SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE, COALESCE(COLUMN_NAME, ''), COALESCE(CARDINALITY, 0)
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = ?
+		ORDER BY TABLE_NAME, INDEX_NAME = 'PRIMARY' DESC, INDEX_NAME, SEQ_IN_INDEX`
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, indexName, column string
+		var nonUnique int
+		var cardinality int64
+		if err := rows.Scan(&tableName, &indexName, &nonUnique, &column, &cardinality); err != nil {
+			continue
+		}
+		table, ok := tables[dbName+"."+tableName]
+		if !ok || table.Stats == nil {
+			continue
+		}
+
+		n := len(table.Stats.Indexes)
+		if n == 0 || table.Stats.Indexes[n-1].Name != indexName {
+			table.Stats.Indexes = append(table.Stats.Indexes, IndexStats{Name: indexName, Unique: nonUnique == 0})
+			n++
+		}
+		index := &table.Stats.Indexes[n-1]
+		if column == "" {
+			// Functional key parts have no column name (8.0.13+)
+			column = "(expression)"
+		}
+		index.Columns = append(index.Columns, column)
+		index.Cardinality = cardinality
+	}
+	return rows.Err()
+}
+
+// getInnoDBIndexStats adds index sizes from the InnoDB persistent statistics, if readable
+func (c *MySQLCollector) getInnoDBIndexStats(dbName string, tables map[string]*TableInfo) {
+	query := `
+		SELECT table_name, index_name, stat_name, stat_value
+		FROM mysql.innodb_index_stats
+		WHERE database_name = ? AND stat_name IN ('size', 'n_leaf_pages')`
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName, indexName, statName string
+		var value int64
+		if err := rows.Scan(&tableName, &indexName, &statName, &value); err != nil {
+			continue
+		}
+		table, ok := tables[dbName+"."+tableName]
+		if !ok || table.Stats == nil {
+			continue
+		}
+		for i := range table.Stats.Indexes {
+			if table.Stats.Indexes[i].Name != indexName {
+				continue
+			}
+			if statName == "size" {
+				table.Stats.Indexes[i].SizePages = value
+			} else {
+				table.Stats.Indexes[i].LeafPages = value
+			}
+		}
+	}
+}
+
+// getHistograms reads persistent histograms from information_schema.COLUMN_STATISTICS (8.0+)
+func (c *MySQLCollector) getHistograms(dbName string, tables map[string]*TableInfo) {
+	query := `
+		SELECT TABLE_NAME, COLUMN_NAME,
+		       COALESCE(JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."histogram-type"')), ''),
+		       COALESCE(JSON_LENGTH(HISTOGRAM, '$.buckets'), 0),
+		       COALESCE(JSON_EXTRACT(HISTOGRAM, '$."sampling-rate"'), 0),
+		       COALESCE(JSON_UNQUOTE(JSON_EXTRACT(HISTOGRAM, '$."last-updated"')), '')
+		FROM information_schema.COLUMN_STATISTICS
+		WHERE SCHEMA_NAME = ?
+		ORDER BY TABLE_NAME, COLUMN_NAME`
+
+	rows, err := c.db.Query(query, dbName)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableName string
+		var histogram ColumnHistogram
+		if err := rows.Scan(&tableName, &histogram.Column, &histogram.Type, &histogram.Buckets,
+			&histogram.SamplingRate, &histogram.LastUpdated); err != nil {
+			continue
+		}
+		if table, ok := tables[dbName+"."+tableName]; ok && table.Stats != nil {
+			table.Stats.Histograms = append(table.Stats.Histograms, histogram)
+		}
+	}
+}
+
 // collectUsers collects user account information
 func (c *MySQLCollector) collectUsers(info *DatabaseInfo) error {
 	var query string
 	if c.version.IsMySQL8OrLater() {
 		query = `
-			SELECT User, Host, plugin, account_locked, password_expired
+			SELECT User, Host, plugin, account_locked, password_expired,
+			       COALESCE(authentication_string, '') = '' as empty_password
 			FROM mysql.user 
 			ORDER BY User, Host`
 	} else {
 		query = `
-			SELECT User, Host, plugin, account_locked, 'N' as password_expired
+			SELECT User, Host, plugin, account_locked, 'N' as password_expired,
+			       COALESCE(authentication_string, '') = '' as empty_password
 			FROM mysql.user 
 			ORDER BY User, Host`
 	}
@@ -296,7 +756,7 @@ func (c *MySQLCollector) collectUsers(info *DatabaseInfo) error {
 		var user UserAccount
 		var plugin, locked, expired sql.NullString
 
-		err := rows.Scan(&user.User, &user.Host, &plugin, &locked, &expired)
+		err := rows.Scan(&user.User, &user.Host, &plugin, &locked, &expired, &user.EmptyPassword)
 		if err != nil {
 			continue
 		}
@@ -350,7 +810,7 @@ func (c *MySQLCollector) collectRoutines(info *DatabaseInfo) error {
 	// Collect both functions and procedures
 	query := `
 		SELECT ROUTINE_NAME, ROUTINE_TYPE, ROUTINE_SCHEMA, DEFINER, CREATED, LAST_ALTERED,
-		       SQL_DATA_ACCESS, SECURITY_TYPE, ROUTINE_DEFINITION, DTD_IDENTIFIER
+		       SQL_DATA_ACCESS, SECURITY_TYPE, ROUTINE_DEFINITION, DTD_IDENTIFIER, ROUTINE_COMMENT
 		FROM information_schema.ROUTINES
 		WHERE ROUTINE_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
 		ORDER BY ROUTINE_SCHEMA, ROUTINE_TYPE, ROUTINE_NAME`
@@ -367,7 +827,7 @@ func (c *MySQLCollector) collectRoutines(info *DatabaseInfo) error {
 
 		err := rows.Scan(&routine.Name, &routine.Type, &routine.Schema, &definer,
 			&created, &lastAltered, &dataAccess, &securityType,
-			&definition, &returns)
+			&definition, &returns, &routine.Comment)
 		if err != nil {
 			continue
 		}
@@ -404,6 +864,12 @@ func (c *MySQLCollector) collectRoutines(info *DatabaseInfo) error {
 			routine.Parameters = params
 		}
 
+		// Get DDL; ROUTINE_DEFINITION is only the body
+		ddl, err := c.getShowCreate(fmt.Sprintf("SHOW CREATE %s `%s`.`%s`", routine.Type, routine.Schema, routine.Name), "Create "+routine.Type)
+		if err == nil {
+			routine.DDL = ddl
+		}
+
 		info.Routines = append(info.Routines, routine)
 	}
 
@@ -433,6 +899,171 @@ func (c *MySQLCollector) getRoutineParameters(schema, name string) (string, erro
 	return "", nil
 }
 
+// collectTriggers collects all triggers
+func (c *MySQLCollector) collectTriggers(info *DatabaseInfo) error {
+	query := `
+		SELECT TRIGGER_SCHEMA, TRIGGER_NAME, EVENT_OBJECT_TABLE, ACTION_TIMING,
+		       EVENT_MANIPULATION, DEFINER, SQL_MODE, ACTION_STATEMENT, CREATED
+		FROM information_schema.TRIGGERS
+		WHERE TRIGGER_SCHEMA NOT IN ('information_schema', 'performance_schema', 'mysql', 'sys')
+		ORDER BY TRIGGER_SCHEMA, EVENT_OBJECT_TABLE, ACTION_TIMING, EVENT_MANIPULATION, ACTION_ORDER`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger TriggerInfo
+		var definer, sqlMode, statement, created sql.NullString
+
+		err := rows.Scan(&trigger.Schema, &trigger.Name, &trigger.Table, &trigger.Timing,
+			&trigger.Event, &definer, &sqlMode, &statement, &created)
+		if err != nil {
+			continue
+		}
+
+		if definer.Valid {
+			trigger.Definer = definer.String
+		}
+		if sqlMode.Valid {
+			trigger.SQLMode = sqlMode.String
+		}
+		if statement.Valid {
+			trigger.Statement = statement.String
+		}
+		if created.Valid && created.String != "" {
+			if t, err := time.Parse("2006-01-02 15:04:05", created.String); err == nil {
+				trigger.Created = t
+			}
+		}
+
+		// Get DDL
+		ddl, err := c.getShowCreate(fmt.Sprintf("SHOW CREATE TRIGGER `%s`.`%s`", trigger.Schema, trigger.Name), "SQL Original Statement")
+		if err == nil {
+			trigger.DDL = ddl
+		}
+
+		info.Triggers = append(info.Triggers, trigger)
+	}
+
+	return nil
+}
+
+// collectEvents collects all scheduled events
+func (c *MySQLCollector) collectEvents(info *DatabaseInfo) error {
+	query := `
+		SELECT EVENT_SCHEMA, EVENT_NAME, DEFINER, TIME_ZONE, EVENT_TYPE,
+		       EXECUTE_AT, INTERVAL_VALUE, INTERVAL_FIELD, STARTS, ENDS,
+		       STATUS, ON_COMPLETION, SQL_MODE, EVENT_DEFINITION, EVENT_COMMENT,
+		       CREATED, LAST_ALTERED, LAST_EXECUTED
+		FROM information_schema.EVENTS
+		ORDER BY EVENT_SCHEMA, EVENT_NAME`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event EventInfo
+		var definer, timeZone, executeAt, intervalValue, intervalField, starts, ends sql.NullString
+		var status, onCompletion, sqlMode, body, comment sql.NullString
+		var created, lastAltered, lastExecuted sql.NullString
+
+		err := rows.Scan(&event.Schema, &event.Name, &definer, &timeZone, &event.Type,
+			&executeAt, &intervalValue, &intervalField, &starts, &ends,
+			&status, &onCompletion, &sqlMode, &body, &comment,
+			&created, &lastAltered, &lastExecuted)
+		if err != nil {
+			continue
+		}
+
+		event.Definer = definer.String
+		event.TimeZone = timeZone.String
+		event.Status = status.String
+		event.OnCompletion = onCompletion.String
+		event.SQLMode = sqlMode.String
+		event.Body = body.String
+		event.Comment = comment.String
+
+		// Build a readable schedule from the schedule columns
+		if event.Type == "RECURRING" {
+			event.Schedule = fmt.Sprintf("EVERY %s %s", intervalValue.String, intervalField.String)
+			if starts.Valid && starts.String != "" {
+				event.Schedule += " STARTS " + starts.String
+			}
+			if ends.Valid && ends.String != "" {
+				event.Schedule += " ENDS " + ends.String
+			}
+		} else if executeAt.Valid {
+			event.Schedule = "AT " + executeAt.String
+		}
+
+		if created.Valid && created.String != "" {
+			if t, err := time.Parse("2006-01-02 15:04:05", created.String); err == nil {
+				event.Created = t
+			}
+		}
+		if lastAltered.Valid && lastAltered.String != "" {
+			if t, err := time.Parse("2006-01-02 15:04:05", lastAltered.String); err == nil {
+				event.LastAltered = t
+			}
+		}
+		if lastExecuted.Valid && lastExecuted.String != "" {
+			if t, err := time.Parse("2006-01-02 15:04:05", lastExecuted.String); err == nil {
+				event.LastExecuted = t
+			}
+		}
+
+		// Get DDL
+		ddl, err := c.getShowCreate(fmt.Sprintf("SHOW CREATE EVENT `%s`.`%s`", event.Schema, event.Name), "Create Event")
+		if err == nil {
+			event.DDL = ddl
+		}
+
+		info.Events = append(info.Events, event)
+	}
+
+	return nil
+}
+
+// getShowCreate runs a SHOW CREATE statement and returns the named column.
+// The number of columns differs between statements and MySQL versions, so the column is looked up by name.
+func (c *MySQLCollector) getShowCreate(query, column string) (string, error) {
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if !rows.Next() {
+		return "", fmt.Errorf("no result for %s", query)
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+
+	for i, name := range columns {
+		if strings.EqualFold(name, column) {
+			return values[i].String, nil
+		}
+	}
+	return "", fmt.Errorf("column %s not found in result of %s", column, query)
+}
+
 // collectVariables collects all global variables
 func (c *MySQLCollector) collectVariables(info *DatabaseInfo) error {
 	// Try performance_schema first for MySQL 5.7+
@@ -586,6 +1217,9 @@ func (c *MySQLCollector) collectMySQL8Features(info *DatabaseInfo) error {
 		// Don't fail completely if roles collection fails
 		fmt.Printf("Warning: Failed to collect roles: %v\n", err)
 	}
+	if err := c.collectRoleGraph(info); err != nil {
+		fmt.Printf("Warning: Failed to collect role graph: %v\n", err)
+	}
 
 	return nil
 }
@@ -630,6 +1264,70 @@ func (c *MySQLCollector) collectRoles(info *DatabaseInfo) error {
 	return nil
 }
 
+// collectRoleGraph collects the role grants from mysql.role_edges, marking the
+// default roles of mysql.default_roles, together with the mandatory roles and
+// activate_all_roles_on_login that decide which roles are active at login
+func (c *MySQLCollector) collectRoleGraph(info *DatabaseInfo) error {
+	query := `
+		SELECT CONCAT(e.FROM_USER, '@', e.FROM_HOST) as role,
+		       CONCAT(e.TO_USER, '@', e.TO_HOST) as member,
+		       d.USER IS NOT NULL as is_default,
+		       e.WITH_ADMIN_OPTION = 'Y' as admin_option
+		FROM mysql.role_edges e
+		LEFT JOIN mysql.default_roles d
+		       ON d.USER = e.TO_USER AND d.HOST = e.TO_HOST
+		      AND d.DEFAULT_ROLE_USER = e.FROM_USER AND d.DEFAULT_ROLE_HOST = e.FROM_HOST
+		ORDER BY member, role`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	graph := &RoleGraph{}
+	for rows.Next() {
+		var edge RoleEdge
+		if err := rows.Scan(&edge.Role, &edge.Member, &edge.Default, &edge.Admin); err != nil {
+			continue
+		}
+		// Roles granted to a role are active whenever that role is
+		edge.Inherit = true
+		graph.Edges = append(graph.Edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var mandatoryRoles string
+	if err := c.db.QueryRow("SELECT @@global.activate_all_roles_on_login, @@global.mandatory_roles").Scan(
+		&graph.ActivateAllRoles, &mandatoryRoles); err != nil {
+		return err
+	}
+	graph.MandatoryRoles = parseMandatoryRoles(mandatoryRoles)
+
+	info.RoleGraph = graph
+	return nil
+}
+
+// parseMandatoryRoles parses the mandatory_roles value, e.g. "r1,`r2`@`%`,r3@localhost",
+// into user@host names. A role without a host is role@%.
+func parseMandatoryRoles(value string) []string {
+	var roles []string
+	for _, part := range splitTopLevel(value, ',') {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, host := part, "%"
+		if i := indexTopLevel(part, "@"); i >= 0 {
+			name, host = part[:i], part[i+1:]
+		}
+		roles = append(roles, strings.Trim(strings.TrimSpace(name), "`'\"")+"@"+strings.Trim(strings.TrimSpace(host), "`'\""))
+	}
+	return roles
+}
+
 // getRoleMembers gets users who have a specific role
 func (c *MySQLCollector) getRoleMembers(roleName, roleHost string) ([]string, error) {
 	var members []string
@@ -714,6 +1412,40 @@ func (c *MySQLCollector) collectPlugins(info *DatabaseInfo) error {
 	return nil
 }
 
+// collectWorkload collects the top statement digests by total latency from
+// performance_schema. Timers are in picoseconds and converted to milliseconds.
+func (c *MySQLCollector) collectWorkload(info *DatabaseInfo) error {
+	query := `
+		SELECT COALESCE(SCHEMA_NAME, ''), COALESCE(DIGEST, ''), DIGEST_TEXT, COUNT_STAR,
+		       SUM_TIMER_WAIT / 1000000000, AVG_TIMER_WAIT / 1000000000,
+		       SUM_ROWS_EXAMINED, SUM_ROWS_SENT
+		FROM performance_schema.events_statements_summary_by_digest
+		WHERE DIGEST_TEXT IS NOT NULL`
+	args := []interface{}{}
+	if c.config.Database != "" {
+		query += " AND SCHEMA_NAME = ?"
+		args = append(args, c.config.Database)
+	}
+	query += " ORDER BY SUM_TIMER_WAIT DESC LIMIT ?"
+	args = append(args, c.config.WorkloadLimit)
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var digest StatementDigest
+		if err := rows.Scan(&digest.Database, &digest.Digest, &digest.Query, &digest.Calls,
+			&digest.TotalTimeMs, &digest.MeanTimeMs, &digest.RowsExamined, &digest.RowsReturned); err != nil {
+			continue
+		}
+		info.Workload = append(info.Workload, digest)
+	}
+	return rows.Err()
+}
+
 // collectReplicationInfo collects replication information
 func (c *MySQLCollector) collectReplicationInfo(info *DatabaseInfo) error {
 	replicationInfo := &ReplicationInfo{}
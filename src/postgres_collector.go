@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 )
 
@@ -40,12 +41,17 @@ func (c *PostgreSQLCollector) CollectAll() (*DatabaseInfo, error) {
 		return nil, fmt.Errorf("failed to collect connection info: %v", err)
 	}
 
-	if !c.config.ExceptTables {
-		if err := c.collectTables(info); err != nil {
-			return nil, fmt.Errorf("failed to collect tables: %v", err)
+	// Catalogs are per database: without -database, every database that
+	// accepts connections is visited with its own connection
+	if c.config.Database == "" {
+		if err := c.collectAllDatabases(info); err != nil {
+			return nil, err
 		}
+	} else if err := c.collectDatabaseObjects(info); err != nil {
+		return nil, err
 	}
 
+	// Cluster-wide information
 	if !c.config.ExceptUsers {
 		if err := c.collectUsers(info); err != nil {
 			log.Printf("Warning: failed to collect users: %v", err)
@@ -56,6 +62,64 @@ func (c *PostgreSQLCollector) CollectAll() (*DatabaseInfo, error) {
 		if err := c.collectRoles(info); err != nil {
 			log.Printf("Warning: failed to collect roles: %v", err)
 		}
+		if err := c.collectRoleGraph(info); err != nil {
+			log.Printf("Warning: failed to collect role memberships: %v", err)
+		}
+	}
+
+	if !c.config.ExceptVariables {
+		if err := c.collectVariables(info); err != nil {
+			log.Printf("Warning: failed to collect variables: %v", err)
+		}
+	}
+
+	if c.config.Replication {
+		if err := c.collectReplicationInfo(info); err != nil {
+			log.Printf("Warning: failed to collect replication info: %v", err)
+		}
+	}
+
+	if c.config.Workload {
+		if err := c.collectWorkload(info); err != nil {
+			log.Printf("Warning: failed to collect workload: %v", err)
+		}
+	}
+
+	return info, nil
+}
+
+// collectDatabaseObjects collects the objects of the connected database
+func (c *PostgreSQLCollector) collectDatabaseObjects(info *DatabaseInfo) error {
+	if !c.config.ExceptTables {
+		if err := c.collectTables(info); err != nil {
+			return fmt.Errorf("failed to collect tables: %v", err)
+		}
+		if err := c.collectTableStructure(info); err != nil {
+			log.Printf("Warning: failed to collect table structure: %v", err)
+			dropTableStructure(info.Tables)
+		}
+		if c.config.Stats {
+			if err := c.collectTableStats(info); err != nil {
+				log.Printf("Warning: failed to collect table statistics: %v", err)
+			}
+		}
+	}
+
+	if err := c.collectSchemas(info); err != nil {
+		log.Printf("Warning: failed to collect schemas: %v", err)
+	}
+
+	if !c.config.ExceptTypes {
+		if err := c.collectTypes(info); err != nil {
+			log.Printf("Warning: failed to collect types: %v", err)
+		}
+	}
+
+	// Privileges are rendered as grants of users and roles
+	if !c.config.ExceptUsers || !c.config.ExceptRoles {
+		if err := c.collectPrivileges(info); err != nil {
+			log.Printf("Warning: failed to collect privileges: %v", err)
+		}
 	}
 
 	if !c.config.ExceptStoredProcedures {
@@ -64,9 +128,9 @@ func (c *PostgreSQLCollector) CollectAll() (*DatabaseInfo, error) {
 		}
 	}
 
-	if !c.config.ExceptVariables {
-		if err := c.collectVariables(info); err != nil {
-			log.Printf("Warning: failed to collect variables: %v", err)
+	if !c.config.ExceptTriggers {
+		if err := c.collectEventTriggers(info); err != nil {
+			log.Printf("Warning: failed to collect event triggers: %v", err)
 		}
 	}
 
@@ -76,7 +140,121 @@ func (c *PostgreSQLCollector) CollectAll() (*DatabaseInfo, error) {
 		}
 	}
 
-	return info, nil
+	// Publications and subscriptions are per database, the rest of the
+	// replication information is collected once for the cluster
+	if c.config.Replication {
+		info.ReplicationInfo = &ReplicationInfo{}
+		if err := c.getPublications(info.ReplicationInfo); err != nil {
+			log.Printf("Warning: failed to collect publications: %v", err)
+		}
+		if err := c.getSubscriptions(info.ReplicationInfo); err != nil {
+			log.Printf("Warning: failed to collect subscriptions: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// collectAllDatabases collects the objects of every database that is not a template,
+// accepts connections and grants CONNECT to the current user.
+// Every collected object is labeled with its database.
+func (c *PostgreSQLCollector) collectAllDatabases(info *DatabaseInfo) error {
+	query := `
+		SELECT datname, datname = current_database()
+		FROM pg_catalog.pg_database
+		WHERE NOT datistemplate
+		  AND datallowconn
+		  AND has_database_privilege(datname, 'CONNECT')
+		ORDER BY datname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to list databases: %v", err)
+	}
+	defer rows.Close()
+
+	type database struct {
+		name    string
+		current bool
+	}
+	var databases []database
+	for rows.Next() {
+		var d database
+		if err := rows.Scan(&d.name, &d.current); err != nil {
+			continue
+		}
+		databases = append(databases, d)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, d := range databases {
+		config := *c.config
+		config.Database = d.name
+
+		db := c.db
+		if !d.current {
+			db, err = connectToPostgreSQL(&config)
+			if err != nil {
+				log.Printf("Warning: failed to connect to database %s: %v", d.name, err)
+				continue
+			}
+		}
+
+		dbInfo := &DatabaseInfo{}
+		collector := &PostgreSQLCollector{db: db, version: c.version, config: &config}
+		err = collector.collectDatabaseObjects(dbInfo)
+		if !d.current {
+			db.Close()
+		}
+		if err != nil {
+			log.Printf("Warning: failed to collect database %s: %v", d.name, err)
+			continue
+		}
+
+		// Tables are labeled by getTablesForSchema
+		info.Tables = append(info.Tables, dbInfo.Tables...)
+		for _, schema := range dbInfo.Schemas {
+			schema.Database = d.name
+			info.Schemas = append(info.Schemas, schema)
+		}
+		for _, typeInfo := range dbInfo.Types {
+			typeInfo.Database = d.name
+			info.Types = append(info.Types, typeInfo)
+		}
+		for _, priv := range dbInfo.Privileges {
+			priv.Database = d.name
+			info.Privileges = append(info.Privileges, priv)
+		}
+		for _, routine := range dbInfo.Routines {
+			routine.Database = d.name
+			info.Routines = append(info.Routines, routine)
+		}
+		for _, trigger := range dbInfo.EventTriggers {
+			trigger.Database = d.name
+			info.EventTriggers = append(info.EventTriggers, trigger)
+		}
+		for _, ext := range dbInfo.Extensions {
+			ext.Database = d.name
+			info.Extensions = append(info.Extensions, ext)
+		}
+		if dbInfo.ReplicationInfo != nil {
+			if info.ReplicationInfo == nil {
+				info.ReplicationInfo = &ReplicationInfo{}
+			}
+			for _, pub := range dbInfo.ReplicationInfo.Publications {
+				pub.Database = d.name
+				info.ReplicationInfo.Publications = append(info.ReplicationInfo.Publications, pub)
+			}
+			for _, sub := range dbInfo.ReplicationInfo.Subscriptions {
+				sub.Database = d.name
+				info.ReplicationInfo.Subscriptions = append(info.ReplicationInfo.Subscriptions, sub)
+			}
+		}
+	}
+	return nil
 }
 
 func (c *PostgreSQLCollector) collectConnectionInfo(info *DatabaseInfo) error {
@@ -124,6 +302,33 @@ func (c *PostgreSQLCollector) getSchemas() ([]string, error) {
 	return schemas, nil
 }
 
+// collectSchemas collects the user schemas with their owners and comments
+func (c *PostgreSQLCollector) collectSchemas(info *DatabaseInfo) error {
+	query := `
+		SELECT n.nspname, pg_catalog.pg_get_userbyid(n.nspowner),
+		       COALESCE(pg_catalog.obj_description(n.oid, 'pg_namespace'), '')
+		FROM pg_catalog.pg_namespace n
+		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		  AND n.nspname NOT LIKE 'pg_temp_%'
+		  AND n.nspname NOT LIKE 'pg_toast_temp_%'
+		ORDER BY n.nspname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schema SchemaInfo
+		if err := rows.Scan(&schema.Name, &schema.Owner, &schema.Comment); err != nil {
+			continue
+		}
+		info.Schemas = append(info.Schemas, schema)
+	}
+	return rows.Err()
+}
+
 func (c *PostgreSQLCollector) collectTables(info *DatabaseInfo) error {
 	schemas, err := c.getSchemas()
 	if err != nil {
@@ -141,11 +346,27 @@ func (c *PostgreSQLCollector) collectTables(info *DatabaseInfo) error {
 }
 
 func (c *PostgreSQLCollector) getTablesForSchema(schema string) ([]TableInfo, error) {
+	// pg_class instead of information_schema.tables so that materialized views,
+	// sequences, foreign tables and partitioning details are visible
 	query := `
-		SELECT t.table_name, t.table_type
-		FROM information_schema.tables t
-		WHERE t.table_schema = $1
-		ORDER BY t.table_name`
+		SELECT c.relname, c.relkind, pg_catalog.pg_get_userbyid(c.relowner) as owner,
+		       c.relpersistence = 'u' as unlogged,
+		       COALESCE(pn.nspname || '.' || p.relname, '') as partition_of,
+		       COALESCE(pg_catalog.pg_get_expr(c.relpartbound, c.oid), '') as partition_bound,
+		       CASE WHEN c.relkind = 'p' THEN pg_catalog.pg_get_partkeydef(c.oid) ELSE '' END as partition_key
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid AND c.relispartition
+		LEFT JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+		LEFT JOIN pg_catalog.pg_namespace pn ON pn.oid = p.relnamespace
+		WHERE n.nspname = $1
+		  AND c.relkind IN ('r', 'p', 'v', 'm', 'S', 'f')
+		  -- identity column sequences are part of the table definition
+		  AND NOT EXISTS (
+			SELECT 1 FROM pg_catalog.pg_depend d
+			WHERE d.classid = 'pg_catalog.pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'i'
+		  )
+		ORDER BY c.relname`
 
 	rows, err := c.db.Query(query, schema)
 	if err != nil {
@@ -156,8 +377,8 @@ func (c *PostgreSQLCollector) getTablesForSchema(schema string) ([]TableInfo, er
 	var tables []TableInfo
 	for rows.Next() {
 		var table TableInfo
-		var tableType string
-		if err := rows.Scan(&table.Name, &tableType); err != nil {
+		var relkind string
+		if err := rows.Scan(&table.Name, &relkind, &table.Owner, &table.Unlogged, &table.PartitionOf, &table.PartitionBound, &table.PartitionKey); err != nil {
 			continue
 		}
 
@@ -165,197 +386,339 @@ func (c *PostgreSQLCollector) getTablesForSchema(schema string) ([]TableInfo, er
 		table.Database = c.config.Database
 
 		// Normalize type to match MySQL convention
-		switch tableType {
-		case "BASE TABLE":
+		switch relkind {
+		case "r", "p":
 			table.Type = "BASE TABLE"
-		case "VIEW":
+		case "v":
 			table.Type = "VIEW"
-		default:
-			table.Type = tableType
+		case "m":
+			table.Type = "MATERIALIZED VIEW"
+		case "S":
+			table.Type = "SEQUENCE"
+		case "f":
+			table.Type = "FOREIGN TABLE"
 		}
 
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range tables {
+		table := &tables[i]
+
 		// Get table metadata
-		if table.Type == "BASE TABLE" {
-			c.getTableMetadata(&table)
-		}
+		c.getTableMetadata(table)
 
 		// Get DDL
-		ddl, err := c.getTableDDL(schema, table.Name, table.Type)
+		ddl, err := c.getTableDDL(table)
 		if err == nil {
 			table.DDL = ddl
 		}
-
-		tables = append(tables, table)
 	}
 	return tables, nil
 }
 
 func (c *PostgreSQLCollector) getTableMetadata(table *TableInfo) {
-	// Get row count estimate and table size info from pg_class
 	query := `
-		SELECT
-			COALESCE(pg_size_pretty(pg_total_relation_size(c.oid)), '') as total_size,
-			obj_description(c.oid, 'pg_class') as comment
+		SELECT obj_description(c.oid, 'pg_class') as comment
 		FROM pg_catalog.pg_class c
 		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
 		WHERE n.nspname = $1 AND c.relname = $2`
 
-	var totalSize sql.NullString
 	var comment sql.NullString
-	err := c.db.QueryRow(query, table.Schema, table.Name).Scan(&totalSize, &comment)
-	if err == nil {
-		if comment.Valid {
-			table.Comment = comment.String
-		}
-		if totalSize.Valid {
-			table.RowFormat = totalSize.String // Reuse RowFormat for size display
-		}
+	err := c.db.QueryRow(query, table.Schema, table.Name).Scan(&comment)
+	if err == nil && comment.Valid {
+		table.Comment = comment.String
 	}
 }
 
-func (c *PostgreSQLCollector) getTableDDL(schema, name, tableType string) (string, error) {
-	if tableType == "VIEW" {
-		return c.getViewDDL(schema, name)
+// collectTableStructure attaches the columns, indexes, foreign keys and check constraints
+// of the collected tables, views and foreign tables from pg_attribute, pg_index and pg_constraint
+func (c *PostgreSQLCollector) collectTableStructure(info *DatabaseInfo) error {
+	tables := make(map[string]*TableInfo)
+	for i := range info.Tables {
+		if info.Tables[i].Type != "SEQUENCE" {
+			tables[info.Tables[i].Schema+"."+info.Tables[i].Name] = &info.Tables[i]
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	if err := c.getColumns(tables); err != nil {
+		return err
+	}
+	if err := c.getIndexes(tables); err != nil {
+		return err
 	}
-	return c.buildTableDDL(schema, name)
+	return c.getConstraints(tables)
 }
 
-func (c *PostgreSQLCollector) getViewDDL(schema, name string) (string, error) {
-	var definition string
-	err := c.db.QueryRow("SELECT pg_get_viewdef($1||'.'||$2, true)", schema, name).Scan(&definition)
+// getColumns reads the columns of the tables and views from pg_attribute
+func (c *PostgreSQLCollector) getColumns(tables map[string]*TableInfo) error {
+	// Identity columns exist from PostgreSQL 10, generated columns from 12
+	identity, generated := "''", "false"
+	if c.version.IsAtLeast(10) {
+		identity = "CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE '' END"
+	}
+	if c.version.IsAtLeast(12) {
+		generated = "a.attgenerated = 's'"
+	}
+	query := fmt.Sprintf(`
+		SELECT n.nspname, c.relname, a.attname, a.attnum,
+		       pg_catalog.format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+		       COALESCE(pg_catalog.pg_get_expr(d.adbin, d.adrelid), ''),
+		       CASE WHEN a.attcollation <> t.typcollation THEN COALESCE(co.collname, '') ELSE '' END,
+		       %s, %s,
+		       COALESCE(pg_catalog.col_description(c.oid, a.attnum), '')
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+		LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		LEFT JOIN pg_catalog.pg_collation co ON co.oid = a.attcollation
+		WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f')
+		  AND a.attnum > 0 AND NOT a.attisdropped
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%%'
+		ORDER BY n.nspname, c.relname, a.attnum`, identity, generated)
+
+	rows, err := c.db.Query(query)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return fmt.Sprintf("CREATE VIEW %s.%s AS\n%s", schema, name, definition), nil
+	defer rows.Close()
+
+	for rows.Next() {
+		var schema, tableName, columnDefault string
+		var isGenerated bool
+		var column ColumnInfo
+		if err := rows.Scan(&schema, &tableName, &column.Name, &column.Position, &column.Type, &column.Nullable,
+			&columnDefault, &column.Collation, &column.Identity, &isGenerated, &column.Comment); err != nil {
+			continue
+		}
+		table, ok := tables[schema+"."+tableName]
+		if !ok {
+			continue
+		}
+		// The expression of a generated column is stored as its default
+		if isGenerated {
+			column.Generated = columnDefault
+		} else {
+			column.Default = columnDefault
+		}
+		table.Columns = append(table.Columns, column)
+	}
+	return rows.Err()
 }
 
-func (c *PostgreSQLCollector) buildTableDDL(schema, name string) (string, error) {
-	// Get columns
-	colQuery := `
-		SELECT column_name, data_type, character_maximum_length,
-		       column_default, is_nullable, udt_name,
-		       numeric_precision, numeric_scale
-		FROM information_schema.columns
-		WHERE table_schema = $1 AND table_name = $2
-		ORDER BY ordinal_position`
+// getIndexes reads the indexes of the tables and materialized views from pg_index
+func (c *PostgreSQLCollector) getIndexes(tables map[string]*TableInfo) error {
+	query := `
+		SELECT n.nspname, t.relname, i.relname,
+		       array_to_string(ARRAY(
+		           SELECT pg_catalog.pg_get_indexdef(x.indexrelid, k, true)
+		           FROM generate_series(1, x.indnkeyatts) k ORDER BY k
+		       ), E'\n'),
+		       x.indisprimary, x.indisunique, am.amname,
+		       COALESCE(pg_catalog.pg_get_expr(x.indpred, x.indrelid, true), '')
+		FROM pg_catalog.pg_index x
+		JOIN pg_catalog.pg_class i ON i.oid = x.indexrelid
+		JOIN pg_catalog.pg_class t ON t.oid = x.indrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_catalog.pg_am am ON am.oid = i.relam
+		WHERE t.relkind IN ('r', 'p', 'm')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%'
+		ORDER BY n.nspname, t.relname, x.indisprimary DESC, i.relname`
 
-	rows, err := c.db.Query(colQuery, schema, name)
+	rows, err := c.db.Query(query)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer rows.Close()
 
-	var columns []string
 	for rows.Next() {
-		var colName, dataType, isNullable, udtName string
-		var charMaxLen, numPrecision, numScale sql.NullInt64
-		var colDefault sql.NullString
-
-		if err := rows.Scan(&colName, &dataType, &charMaxLen, &colDefault, &isNullable, &udtName, &numPrecision, &numScale); err != nil {
+		var schema, tableName, columns string
+		var index IndexInfo
+		if err := rows.Scan(&schema, &tableName, &index.Name, &columns, &index.Primary, &index.Unique,
+			&index.Method, &index.Predicate); err != nil {
 			continue
 		}
+		if table, ok := tables[schema+"."+tableName]; ok {
+			index.Columns = strings.Split(columns, "\n")
+			table.Indexes = append(table.Indexes, index)
+		}
+	}
+	return rows.Err()
+}
 
-		col := fmt.Sprintf("    %s", colName)
+// foreignKeyActions maps pg_constraint.confupdtype and confdeltype to the referential actions
+var foreignKeyActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
 
-		// Determine type display
-		switch dataType {
-		case "character varying":
-			if charMaxLen.Valid {
-				col += fmt.Sprintf(" varchar(%d)", charMaxLen.Int64)
-			} else {
-				col += " varchar"
-			}
-		case "character":
-			if charMaxLen.Valid {
-				col += fmt.Sprintf(" char(%d)", charMaxLen.Int64)
-			} else {
-				col += " char"
-			}
-		case "numeric":
-			if numPrecision.Valid && numScale.Valid {
-				col += fmt.Sprintf(" numeric(%d,%d)", numPrecision.Int64, numScale.Int64)
-			} else if numPrecision.Valid {
-				col += fmt.Sprintf(" numeric(%d)", numPrecision.Int64)
-			} else {
-				col += " numeric"
-			}
-		case "ARRAY":
-			col += " " + udtName
-		case "USER-DEFINED":
-			col += " " + udtName
-		default:
-			col += " " + dataType
-		}
+// getConstraints reads the foreign keys and check constraints of the tables from pg_constraint
+func (c *PostgreSQLCollector) getConstraints(tables map[string]*TableInfo) error {
+	query := `
+		SELECT n.nspname, t.relname, con.conname, con.contype,
+		       array_to_string(ARRAY(
+		           SELECT a.attname FROM unnest(con.conkey) WITH ORDINALITY k(attnum, ord)
+		           JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ), E'\n'),
+		       COALESCE(rn.nspname, ''), COALESCE(r.relname, ''),
+		       array_to_string(ARRAY(
+		           SELECT a.attname FROM unnest(con.confkey) WITH ORDINALITY k(attnum, ord)
+		           JOIN pg_catalog.pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ), E'\n'),
+		       con.confupdtype, con.confdeltype,
+		       CASE WHEN con.contype = 'c' THEN pg_catalog.pg_get_expr(con.conbin, con.conrelid, true) ELSE '' END
+		FROM pg_catalog.pg_constraint con
+		JOIN pg_catalog.pg_class t ON t.oid = con.conrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN pg_catalog.pg_class r ON r.oid = con.confrelid
+		LEFT JOIN pg_catalog.pg_namespace rn ON rn.oid = r.relnamespace
+		WHERE con.contype IN ('f', 'c')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%'
+		ORDER BY n.nspname, t.relname, con.contype, con.conname`
 
-		if isNullable == "NO" {
-			col += " NOT NULL"
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schema, tableName, name, contype, columns, refSchema, refTable, refColumns, onUpdate, onDelete, expression string
+		if err := rows.Scan(&schema, &tableName, &name, &contype, &columns, &refSchema, &refTable, &refColumns,
+			&onUpdate, &onDelete, &expression); err != nil {
+			continue
 		}
-		if colDefault.Valid {
-			col += fmt.Sprintf(" DEFAULT %s", colDefault.String)
+		table, ok := tables[schema+"."+tableName]
+		if !ok {
+			continue
 		}
 
-		columns = append(columns, col)
+		if contype == "c" {
+			table.CheckConstraints = append(table.CheckConstraints, CheckConstraintInfo{Name: name, Expression: expression})
+			continue
+		}
+		table.ForeignKeys = append(table.ForeignKeys, ForeignKeyInfo{
+			Name:       name,
+			Columns:    strings.Split(columns, "\n"),
+			RefSchema:  refSchema,
+			RefTable:   refTable,
+			RefColumns: strings.Split(refColumns, "\n"),
+			OnUpdate:   foreignKeyActions[onUpdate],
+			OnDelete:   foreignKeyActions[onDelete],
+		})
 	}
+	return rows.Err()
+}
 
-	// Get constraints
-	constraintQuery := `
-		SELECT
-			conname,
-			pg_get_constraintdef(c.oid, true) as condef
-		FROM pg_catalog.pg_constraint c
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.connamespace
-		JOIN pg_catalog.pg_class cl ON cl.oid = c.conrelid
-		WHERE n.nspname = $1 AND cl.relname = $2
-		ORDER BY contype, conname`
-
-	cRows, err := c.db.Query(constraintQuery, schema, name)
-	if err == nil {
-		defer cRows.Close()
-		for cRows.Next() {
-			var conName, conDef string
-			if err := cRows.Scan(&conName, &conDef); err != nil {
-				continue
-			}
-			columns = append(columns, fmt.Sprintf("    CONSTRAINT %s %s", conName, conDef))
+// collectTableStats attaches sizes, reltuples and the activity counters of
+// pg_stat_user_tables / pg_stat_user_indexes to the collected tables and materialized views.
+// The counters accumulate since the last statistics reset.
+func (c *PostgreSQLCollector) collectTableStats(info *DatabaseInfo) error {
+	tables := make(map[string]*TableInfo)
+	for i := range info.Tables {
+		if info.Tables[i].Type == "BASE TABLE" || info.Tables[i].Type == "MATERIALIZED VIEW" {
+			tables[info.Tables[i].Schema+"."+info.Tables[i].Name] = &info.Tables[i]
 		}
 	}
+	if len(tables) == 0 {
+		return nil
+	}
 
-	ddl := fmt.Sprintf("CREATE TABLE %s.%s (\n%s\n);", schema, name, strings.Join(columns, ",\n"))
+	query := `
+		SELECT n.nspname, c.relname,
+		       GREATEST(c.reltuples, 0)::bigint,
+		       pg_relation_size(c.oid), pg_indexes_size(c.oid),
+		       CASE WHEN c.reltoastrelid = 0 THEN 0 ELSE pg_total_relation_size(c.reltoastrelid) END,
+		       pg_total_relation_size(c.oid),
+		       COALESCE(s.n_live_tup, 0), COALESCE(s.n_dead_tup, 0),
+		       COALESCE(s.seq_scan, 0), COALESCE(s.idx_scan, 0),
+		       COALESCE(to_char(s.last_vacuum, 'YYYY-MM-DD HH24:MI:SS'), ''),
+		       COALESCE(to_char(s.last_autovacuum, 'YYYY-MM-DD HH24:MI:SS'), ''),
+		       COALESCE(to_char(s.last_analyze, 'YYYY-MM-DD HH24:MI:SS'), ''),
+		       COALESCE(to_char(s.last_autoanalyze, 'YYYY-MM-DD HH24:MI:SS'), '')
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_stat_user_tables s ON s.relid = c.oid
+		WHERE c.relkind IN ('r', 'p', 'm')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%'`
 
-	// Get indexes (non-constraint)
-	idxQuery := `
-		SELECT indexdef
-		FROM pg_indexes
-		WHERE schemaname = $1 AND tablename = $2
-		  AND indexname NOT IN (
-			SELECT conname FROM pg_catalog.pg_constraint
-			WHERE connamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1)
-			  AND conrelid = (SELECT oid FROM pg_class WHERE relname = $2 AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1))
-		  )`
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
 
-	idxRows, err := c.db.Query(idxQuery, schema, name)
-	if err == nil {
-		defer idxRows.Close()
-		for idxRows.Next() {
-			var indexDef string
-			if err := idxRows.Scan(&indexDef); err != nil {
-				continue
-			}
-			ddl += "\n" + indexDef + ";"
+	for rows.Next() {
+		var schema, name string
+		var stats TableStats
+		if err := rows.Scan(&schema, &name, &stats.RowEstimate, &stats.DataLength, &stats.IndexLength,
+			&stats.ToastLength, &stats.TotalLength, &stats.LiveTuples, &stats.DeadTuples,
+			&stats.SeqScans, &stats.IndexScans, &stats.LastVacuum, &stats.LastAutovacuum,
+			&stats.LastAnalyze, &stats.LastAutoanalyze); err != nil {
+			continue
+		}
+		if table, ok := tables[schema+"."+name]; ok {
+			table.Stats = &stats
 		}
 	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
 
-	return ddl, nil
+	return c.getIndexStats(tables)
 }
 
-func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
+// getIndexStats adds per-index scan counts and sizes. An index is reported as unused
+// when it was never scanned and enforces no constraint (unique, primary key, exclusion).
+// The index of a partitioned table has no statistics of its own, so the scans and
+// sizes of the indexes of its partitions are added up.
+func (c *PostgreSQLCollector) getIndexStats(tables map[string]*TableInfo) error {
 	query := `
-		SELECT rolname, rolsuper, rolcreaterole, rolcreatedb,
-		       rolcanlogin, rolreplication, rolconnlimit, rolvaliduntil
-		FROM pg_catalog.pg_roles
-		WHERE rolcanlogin = true
-		  AND rolname NOT LIKE 'pg_%'
-		ORDER BY rolname`
+		SELECT n.nspname, t.relname, i.relname,
+		       array_to_string(ARRAY(
+		           SELECT pg_get_indexdef(x.indexrelid, k, false)
+		           FROM generate_series(1, x.indnkeyatts) k ORDER BY k
+		       ), E'\n'),
+		       x.indisunique, s.scans, s.size,
+		       s.scans = 0 AND NOT x.indisunique
+		           AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_constraint con WHERE con.conindid = x.indexrelid)
+		FROM pg_catalog.pg_index x
+		JOIN pg_catalog.pg_class i ON i.oid = x.indexrelid
+		JOIN pg_catalog.pg_class t ON t.oid = x.indrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+		CROSS JOIN LATERAL (
+		    WITH RECURSIVE tree(oid) AS (
+		        SELECT x.indexrelid
+		        UNION ALL
+		        SELECT inh.inhrelid FROM pg_catalog.pg_inherits inh JOIN tree ON inh.inhparent = tree.oid
+		    )
+		    SELECT COALESCE(SUM(st.idx_scan), 0)::bigint as scans,
+		           COALESCE(SUM(pg_relation_size(tree.oid)), 0)::bigint as size
+		    FROM tree
+		    LEFT JOIN pg_catalog.pg_stat_user_indexes st ON st.indexrelid = tree.oid
+		) s
+		WHERE t.relkind IN ('r', 'p', 'm')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%'
+		ORDER BY n.nspname, t.relname, x.indisprimary DESC, i.relname`
 
 	rows, err := c.db.Query(query)
 	if err != nil {
@@ -364,13 +727,514 @@ func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
 	defer rows.Close()
 
 	for rows.Next() {
-		var user UserAccount
-		var rolSuper, rolCreateRole, rolCreateDB, rolCanLogin, rolReplication bool
+		var schema, tableName, columns string
+		var index IndexStats
+		if err := rows.Scan(&schema, &tableName, &index.Name, &columns, &index.Unique,
+			&index.Scans, &index.SizeBytes, &index.Unused); err != nil {
+			continue
+		}
+		table, ok := tables[schema+"."+tableName]
+		if !ok || table.Stats == nil {
+			continue
+		}
+		index.Columns = strings.Split(columns, "\n")
+		table.Stats.Indexes = append(table.Stats.Indexes, index)
+	}
+	return rows.Err()
+}
+
+func (c *PostgreSQLCollector) getTableDDL(table *TableInfo) (string, error) {
+	schema, name := table.Schema, table.Name
+
+	var ddl string
+	var err error
+	switch {
+	case table.Type == "VIEW":
+		ddl, err = c.getViewDDL(schema, name)
+	case table.Type == "MATERIALIZED VIEW":
+		ddl, err = c.getMaterializedViewDDL(schema, name)
+	case table.Type == "SEQUENCE":
+		// Sequences have no triggers or rules
+		return c.getSequenceDDL(schema, name)
+	case table.PartitionOf != "":
+		ddl = c.buildPartitionDDL(table)
+	default:
+		ddl, err = c.buildTableDDL(table)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Row-level security and policies
+	if table.Type == "BASE TABLE" {
+		for _, def := range c.getRowSecurityDefs(table) {
+			ddl += "\n" + def + ";"
+		}
+	}
+
+	// Triggers and rules defined on the table or view
+	for _, def := range c.getTriggerAndRuleDefs(schema, name) {
+		ddl += "\n" + def + ";"
+	}
+	return ddl, nil
+}
+
+// getRowSecurityDefs collects the row-level security flags and policies of a table
+// and returns the ALTER TABLE and CREATE POLICY statements for them
+func (c *PostgreSQLCollector) getRowSecurityDefs(table *TableInfo) []string {
+	var defs []string
+
+	flagQuery := `
+		SELECT c.relrowsecurity, c.relforcerowsecurity
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`
+
+	if err := c.db.QueryRow(flagQuery, table.Schema, table.Name).Scan(&table.RowSecurity, &table.ForceRowSecurity); err == nil {
+		if table.RowSecurity {
+			defs = append(defs, fmt.Sprintf("ALTER TABLE %s.%s ENABLE ROW LEVEL SECURITY", table.Schema, table.Name))
+		}
+		if table.ForceRowSecurity {
+			defs = append(defs, fmt.Sprintf("ALTER TABLE %s.%s FORCE ROW LEVEL SECURITY", table.Schema, table.Name))
+		}
+	}
+
+	// pg_policies is a view over pg_policy with the roles and expressions resolved
+	policyQuery := `
+		SELECT policyname, cmd, permissive, array_to_string(roles, ','),
+		       COALESCE(qual, ''), COALESCE(with_check, '')
+		FROM pg_catalog.pg_policies
+		WHERE schemaname = $1 AND tablename = $2
+		ORDER BY policyname`
+
+	rows, err := c.db.Query(policyQuery, table.Schema, table.Name)
+	if err != nil {
+		return defs
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var policy PolicyInfo
+		var roles string
+		if err := rows.Scan(&policy.Name, &policy.Command, &policy.Permissive, &roles, &policy.Using, &policy.WithCheck); err != nil {
+			continue
+		}
+		if roles != "" {
+			policy.Roles = strings.Split(roles, ",")
+		}
+		table.Policies = append(table.Policies, policy)
+
+		def := fmt.Sprintf("CREATE POLICY %s ON %s.%s", policy.Name, table.Schema, table.Name)
+		if policy.Permissive == "RESTRICTIVE" {
+			def += "\n    AS RESTRICTIVE"
+		}
+		def += "\n    FOR " + policy.Command
+		if len(policy.Roles) > 0 {
+			def += "\n    TO " + strings.Join(policy.Roles, ", ")
+		}
+		if policy.Using != "" {
+			def += fmt.Sprintf("\n    USING (%s)", policy.Using)
+		}
+		if policy.WithCheck != "" {
+			def += fmt.Sprintf("\n    WITH CHECK (%s)", policy.WithCheck)
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// getTriggerAndRuleDefs returns CREATE TRIGGER and CREATE RULE statements for a relation.
+// Internal triggers (e.g. for foreign keys), triggers of a partition cloned from its
+// parent and the _RETURN rule of views are skipped.
+func (c *PostgreSQLCollector) getTriggerAndRuleDefs(schema, name string) []string {
+	var defs []string
+
+	// Cloned triggers are internal before PostgreSQL 13, which added tgparentid
+	notCloned := ""
+	if c.version.IsAtLeast(13) {
+		notCloned = "AND t.tgparentid = 0"
+	}
+	triggerQuery := fmt.Sprintf(`
+		SELECT pg_catalog.pg_get_triggerdef(t.oid, true)
+		FROM pg_catalog.pg_trigger t
+		JOIN pg_catalog.pg_class cl ON cl.oid = t.tgrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = cl.relnamespace
+		WHERE n.nspname = $1 AND cl.relname = $2
+		  AND NOT t.tgisinternal %s
+		ORDER BY t.tgname`, notCloned)
+
+	rows, err := c.db.Query(triggerQuery, schema, name)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var def string
+			if err := rows.Scan(&def); err != nil {
+				continue
+			}
+			defs = append(defs, def)
+		}
+	}
+
+	ruleQuery := `
+		SELECT pg_catalog.pg_get_ruledef(r.oid, true)
+		FROM pg_catalog.pg_rewrite r
+		JOIN pg_catalog.pg_class cl ON cl.oid = r.ev_class
+		JOIN pg_catalog.pg_namespace n ON n.oid = cl.relnamespace
+		WHERE n.nspname = $1 AND cl.relname = $2
+		  AND r.rulename <> '_RETURN'
+		ORDER BY r.rulename`
+
+	rRows, err := c.db.Query(ruleQuery, schema, name)
+	if err == nil {
+		defer rRows.Close()
+		for rRows.Next() {
+			var def string
+			if err := rRows.Scan(&def); err != nil {
+				continue
+			}
+			// pg_get_ruledef already ends with a semicolon
+			defs = append(defs, strings.TrimSuffix(def, ";"))
+		}
+	}
+
+	return defs
+}
+
+func (c *PostgreSQLCollector) getViewDDL(schema, name string) (string, error) {
+	var definition string
+	err := c.db.QueryRow("SELECT pg_get_viewdef($1||'.'||$2, true)", schema, name).Scan(&definition)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("CREATE VIEW %s.%s AS\n%s", schema, name, definition), nil
+}
+
+func (c *PostgreSQLCollector) getMaterializedViewDDL(schema, name string) (string, error) {
+	var definition string
+	var populated bool
+	query := `
+		SELECT pg_get_viewdef(c.oid, true), c.relispopulated
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`
+	if err := c.db.QueryRow(query, schema, name).Scan(&definition, &populated); err != nil {
+		return "", err
+	}
+
+	ddl := fmt.Sprintf("CREATE MATERIALIZED VIEW %s.%s AS\n%s", schema, name, strings.TrimSuffix(definition, ";"))
+	if !populated {
+		ddl += "\nWITH NO DATA"
+	}
+	ddl += ";"
+
+	// Materialized views can be indexed
+	idxRows, err := c.db.Query("SELECT indexdef FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 ORDER BY indexname", schema, name)
+	if err == nil {
+		defer idxRows.Close()
+		for idxRows.Next() {
+			var indexDef string
+			if err := idxRows.Scan(&indexDef); err != nil {
+				continue
+			}
+			ddl += "\n" + indexDef + ";"
+		}
+	}
+	return ddl, nil
+}
+
+// getSequenceDDL builds CREATE SEQUENCE with the current value and the owning column, if any
+func (c *PostgreSQLCollector) getSequenceDDL(schema, name string) (string, error) {
+	query := `
+		SELECT data_type::text, start_value, min_value, max_value, increment_by,
+		       cycle, cache_size, last_value
+		FROM pg_catalog.pg_sequences
+		WHERE schemaname = $1 AND sequencename = $2`
+
+	var dataType string
+	var start, minValue, maxValue, increment, cache int64
+	var cycle bool
+	var lastValue sql.NullInt64
+	err := c.db.QueryRow(query, schema, name).Scan(&dataType, &start, &minValue, &maxValue, &increment, &cycle, &cache, &lastValue)
+	if err != nil {
+		return "", err
+	}
+
+	ddl := fmt.Sprintf("CREATE SEQUENCE %s.%s\n    AS %s\n    INCREMENT BY %d\n    MINVALUE %d\n    MAXVALUE %d\n    START WITH %d\n    CACHE %d",
+		schema, name, dataType, increment, minValue, maxValue, start, cache)
+	if cycle {
+		ddl += "\n    CYCLE"
+	}
+	ddl += ";"
+
+	// last_value is NULL until nextval has been called (or the role lacks USAGE/SELECT)
+	if lastValue.Valid {
+		ddl += fmt.Sprintf("\nSELECT setval('%s.%s', %d, true);", schema, name, lastValue.Int64)
+	}
+
+	ownerQuery := `
+		SELECT tn.nspname || '.' || t.relname || '.' || a.attname
+		FROM pg_catalog.pg_class s
+		JOIN pg_catalog.pg_namespace sn ON sn.oid = s.relnamespace
+		JOIN pg_catalog.pg_depend d ON d.objid = s.oid AND d.classid = 'pg_catalog.pg_class'::regclass
+		     AND d.refclassid = 'pg_catalog.pg_class'::regclass AND d.deptype = 'a'
+		JOIN pg_catalog.pg_class t ON t.oid = d.refobjid
+		JOIN pg_catalog.pg_namespace tn ON tn.oid = t.relnamespace
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid
+		WHERE sn.nspname = $1 AND s.relname = $2`
+
+	var ownedBy string
+	if err := c.db.QueryRow(ownerQuery, schema, name).Scan(&ownedBy); err == nil {
+		ddl += fmt.Sprintf("\nALTER SEQUENCE %s.%s OWNED BY %s;", schema, name, ownedBy)
+	}
+	return ddl, nil
+}
+
+// getForeignTableServer returns the SERVER and OPTIONS clause of a foreign table
+func (c *PostgreSQLCollector) getForeignTableServer(schema, name string) string {
+	query := `
+		SELECT s.srvname,
+		       COALESCE((SELECT string_agg(quote_ident(o.option_name) || ' ' || quote_literal(o.option_value), ', ')
+		                 FROM pg_catalog.pg_options_to_table(ft.ftoptions) o), '') as options
+		FROM pg_catalog.pg_foreign_table ft
+		JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+		JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`
+
+	var server, options string
+	if err := c.db.QueryRow(query, schema, name).Scan(&server, &options); err != nil {
+		return ""
+	}
+
+	clause := "\nSERVER " + server
+	if options != "" {
+		clause += "\nOPTIONS (" + options + ")"
+	}
+	return clause
+}
+
+func (c *PostgreSQLCollector) buildTableDDL(table *TableInfo) (string, error) {
+	schema, name := table.Schema, table.Name
+
+	// Get columns
+	colQuery := `
+		SELECT column_name, data_type, character_maximum_length,
+		       column_default, is_nullable, udt_schema, udt_name,
+		       numeric_precision, numeric_scale, domain_schema, domain_name
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2
+		ORDER BY ordinal_position`
+
+	rows, err := c.db.Query(colQuery, schema, name)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var colName, dataType, isNullable, udtSchema, udtName string
+		var charMaxLen, numPrecision, numScale sql.NullInt64
+		var colDefault, domainSchema, domainName sql.NullString
+
+		if err := rows.Scan(&colName, &dataType, &charMaxLen, &colDefault, &isNullable, &udtSchema, &udtName,
+			&numPrecision, &numScale, &domainSchema, &domainName); err != nil {
+			continue
+		}
+
+		col := fmt.Sprintf("    %s", colName)
+
+		// Determine type display
+		switch {
+		case domainName.Valid:
+			// Columns of a domain type report the domain's base type in data_type
+			col += fmt.Sprintf(" %s.%s", domainSchema.String, domainName.String)
+		case dataType == "character varying":
+			if charMaxLen.Valid {
+				col += fmt.Sprintf(" varchar(%d)", charMaxLen.Int64)
+			} else {
+				col += " varchar"
+			}
+		case dataType == "character":
+			if charMaxLen.Valid {
+				col += fmt.Sprintf(" char(%d)", charMaxLen.Int64)
+			} else {
+				col += " char"
+			}
+		case dataType == "numeric":
+			if numPrecision.Valid && numScale.Valid {
+				col += fmt.Sprintf(" numeric(%d,%d)", numPrecision.Int64, numScale.Int64)
+			} else if numPrecision.Valid {
+				col += fmt.Sprintf(" numeric(%d)", numPrecision.Int64)
+			} else {
+				col += " numeric"
+			}
+		case dataType == "ARRAY":
+			col += " " + udtName
+		case dataType == "USER-DEFINED":
+			// Schema-qualify so the column refers to the type in the Types section
+			col += fmt.Sprintf(" %s.%s", udtSchema, udtName)
+		default:
+			col += " " + dataType
+		}
+
+		if isNullable == "NO" {
+			col += " NOT NULL"
+		}
+		if colDefault.Valid {
+			col += fmt.Sprintf(" DEFAULT %s", colDefault.String)
+		}
+
+		columns = append(columns, col)
+	}
+
+	// Get constraints
+	columns = append(columns, c.getConstraintDefs(schema, name, false)...)
+
+	create := "CREATE TABLE"
+	if table.Unlogged {
+		create = "CREATE UNLOGGED TABLE"
+	}
+	var suffix string
+	switch {
+	case table.Type == "FOREIGN TABLE":
+		create = "CREATE FOREIGN TABLE"
+		suffix = c.getForeignTableServer(schema, name)
+	case table.PartitionKey != "":
+		suffix = " PARTITION BY " + table.PartitionKey
+	}
+	ddl := fmt.Sprintf("%s %s.%s (\n%s\n)%s;", create, schema, name, strings.Join(columns, ",\n"), suffix)
+
+	// Get indexes (non-constraint)
+	for _, indexDef := range c.getIndexDefs(schema, name) {
+		ddl += "\n" + indexDef + ";"
+	}
+
+	return ddl, nil
+}
+
+// buildPartitionDDL assembles the CREATE TABLE ... PARTITION OF statement of a partition.
+// The columns, and the constraints and indexes cloned from the parent, come with the
+// parent table; those defined on the partition itself are kept.
+func (c *PostgreSQLCollector) buildPartitionDDL(table *TableInfo) string {
+	schema, name := table.Schema, table.Name
+
+	create := "CREATE TABLE"
+	if table.Unlogged {
+		create = "CREATE UNLOGGED TABLE"
+	}
+	ddl := fmt.Sprintf("%s %s.%s PARTITION OF %s", create, schema, name, table.PartitionOf)
+	if constraints := c.getConstraintDefs(schema, name, true); len(constraints) > 0 {
+		ddl += " (\n" + strings.Join(constraints, ",\n") + "\n)"
+	}
+	ddl += "\n    " + table.PartitionBound
+	if table.PartitionKey != "" {
+		// A partition that is partitioned itself
+		ddl += "\n    PARTITION BY " + table.PartitionKey
+	}
+	ddl += ";"
+
+	for _, indexDef := range c.getIndexDefs(schema, name) {
+		ddl += "\n" + indexDef + ";"
+	}
+	return ddl
+}
+
+// getConstraintDefs returns the constraints of a table as CONSTRAINT clauses of
+// CREATE TABLE. With localOnly, constraints inherited or cloned from a parent
+// table are left out.
+func (c *PostgreSQLCollector) getConstraintDefs(schema, name string, localOnly bool) []string {
+	filter := ""
+	if localOnly {
+		filter = "AND c.conislocal"
+		// conparentid (PostgreSQL 11+) links the keys cloned from the parent's
+		if c.version.IsAtLeast(11) {
+			filter += " AND c.conparentid = 0"
+		}
+	}
+	constraintQuery := fmt.Sprintf(`
+		SELECT
+			conname,
+			pg_get_constraintdef(c.oid, true) as condef
+		FROM pg_catalog.pg_constraint c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.connamespace
+		JOIN pg_catalog.pg_class cl ON cl.oid = c.conrelid
+		WHERE n.nspname = $1 AND cl.relname = $2
+		  %s
+		ORDER BY contype, conname`, filter)
+
+	var defs []string
+	cRows, err := c.db.Query(constraintQuery, schema, name)
+	if err != nil {
+		return defs
+	}
+	defer cRows.Close()
+	for cRows.Next() {
+		var conName, conDef string
+		if err := cRows.Scan(&conName, &conDef); err != nil {
+			continue
+		}
+		defs = append(defs, fmt.Sprintf("    CONSTRAINT %s %s", conName, conDef))
+	}
+	return defs
+}
+
+// getIndexDefs returns the CREATE INDEX statements of the indexes of a table that
+// neither back a constraint nor are attached to an index of the parent table
+func (c *PostgreSQLCollector) getIndexDefs(schema, name string) []string {
+	idxQuery := `
+		SELECT indexdef
+		FROM pg_indexes
+		WHERE schemaname = $1 AND tablename = $2
+		  AND indexname NOT IN (
+			SELECT conname FROM pg_catalog.pg_constraint
+			WHERE connamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1)
+			  AND conrelid = (SELECT oid FROM pg_class WHERE relname = $2 AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1))
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM pg_catalog.pg_inherits inh
+			WHERE inh.inhrelid = (SELECT oid FROM pg_class WHERE relname = indexname AND relnamespace = (SELECT oid FROM pg_namespace WHERE nspname = $1))
+		  )`
+
+	var defs []string
+	idxRows, err := c.db.Query(idxQuery, schema, name)
+	if err != nil {
+		return defs
+	}
+	defer idxRows.Close()
+	for idxRows.Next() {
+		var indexDef string
+		if err := idxRows.Scan(&indexDef); err != nil {
+			continue
+		}
+		defs = append(defs, indexDef)
+	}
+	return defs
+}
+
+func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
+	query := `
+		SELECT rolname, rolsuper, rolcreaterole, rolcreatedb,
+		       rolcanlogin, rolreplication, rolbypassrls, rolconnlimit, rolvaliduntil
+		FROM pg_catalog.pg_roles
+		WHERE rolcanlogin = true
+		  AND rolname NOT LIKE 'pg_%'
+		ORDER BY rolname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user UserAccount
+		var rolSuper, rolCreateRole, rolCreateDB, rolCanLogin, rolReplication, rolBypassRLS bool
 		var rolConnLimit int
 		var rolValidUntil sql.NullString
 
 		if err := rows.Scan(&user.User, &rolSuper, &rolCreateRole, &rolCreateDB,
-			&rolCanLogin, &rolReplication, &rolConnLimit, &rolValidUntil); err != nil {
+			&rolCanLogin, &rolReplication, &rolBypassRLS, &rolConnLimit, &rolValidUntil); err != nil {
 			continue
 		}
 
@@ -378,6 +1242,8 @@ func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
 		user.IsSuperuser = rolSuper
 		user.CanCreateDB = rolCreateDB
 		user.CanCreateRole = rolCreateRole
+		user.Replication = rolReplication
+		user.BypassRLS = rolBypassRLS
 		user.ConnLimit = rolConnLimit
 		if rolValidUntil.Valid {
 			user.ValidUntil = rolValidUntil.String
@@ -397,6 +1263,9 @@ func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
 		if rolReplication {
 			attrs = append(attrs, "REPLICATION")
 		}
+		if rolBypassRLS {
+			attrs = append(attrs, "BYPASSRLS")
+		}
 		if rolConnLimit >= 0 {
 			attrs = append(attrs, fmt.Sprintf("CONNECTION LIMIT %d", rolConnLimit))
 		}
@@ -405,7 +1274,7 @@ func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
 		}
 
 		// Get grants (role memberships and privileges)
-		grants, err := c.getUserGrants(user.User)
+		grants, err := c.getUserGrants(user.User, info.Privileges)
 		if err == nil {
 			user.Grants = grants
 		}
@@ -415,7 +1284,7 @@ func (c *PostgreSQLCollector) collectUsers(info *DatabaseInfo) error {
 	return nil
 }
 
-func (c *PostgreSQLCollector) getUserGrants(rolname string) ([]string, error) {
+func (c *PostgreSQLCollector) getUserGrants(rolname string, privileges []PrivilegeInfo) ([]string, error) {
 	var grants []string
 
 	// Role memberships
@@ -437,30 +1306,192 @@ func (c *PostgreSQLCollector) getUserGrants(rolname string) ([]string, error) {
 		}
 	}
 
-	// Database-level privileges
-	dbGrantQuery := `
-		SELECT datname, datacl
-		FROM pg_catalog.pg_database
-		WHERE datacl IS NOT NULL AND datacl::text LIKE '%' || $1 || '%'`
+	// Object privileges
+	grants = append(grants, grantStatements(privileges, rolname)...)
 
-	dbRows, err := c.db.Query(dbGrantQuery, rolname)
-	if err == nil {
-		defer dbRows.Close()
-		for dbRows.Next() {
-			var datname, datacl string
-			if err := dbRows.Scan(&datname, &datacl); err != nil {
-				continue
+	return grants, nil
+}
+
+// collectPrivileges expands the ACLs of the current database, its schemas, relations, columns,
+// routines and types, and the default privileges, into one row per privilege.
+// An object whose ACL is NULL has the built-in defaults of acldefault(), such as
+// EXECUTE to PUBLIC on functions. Privileges of an object's owner are implicit and are not listed.
+func (c *PostgreSQLCollector) collectPrivileges(info *DatabaseInfo) error {
+	query := `
+		WITH acls AS (
+			SELECT 'DATABASE' as object_type, quote_ident(d.datname) as object, '' as col,
+			       COALESCE(d.datacl, pg_catalog.acldefault('d', d.datdba)) as acl, d.datdba as owner
+			FROM pg_catalog.pg_database d
+			WHERE d.datname = current_database()
+			UNION ALL
+			SELECT 'SCHEMA', quote_ident(n.nspname), '', COALESCE(n.nspacl, pg_catalog.acldefault('n', n.nspowner)), n.nspowner
+			FROM pg_catalog.pg_namespace n
+			UNION ALL
+			SELECT CASE c.relkind WHEN 'S' THEN 'SEQUENCE' ELSE 'TABLE' END,
+			       quote_ident(n.nspname) || '.' || quote_ident(c.relname), '',
+			       COALESCE(c.relacl, pg_catalog.acldefault(CASE c.relkind WHEN 'S' THEN 's' ELSE 'r' END::"char", c.relowner)), c.relowner
+			FROM pg_catalog.pg_class c
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.relkind IN ('r', 'p', 'v', 'm', 'S', 'f')
+			UNION ALL
+			SELECT 'TABLE', quote_ident(n.nspname) || '.' || quote_ident(c.relname), quote_ident(a.attname), a.attacl, c.relowner
+			FROM pg_catalog.pg_attribute a
+			JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE a.attnum > 0 AND NOT a.attisdropped AND a.attacl IS NOT NULL
+			UNION ALL
+			SELECT CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END,
+			       quote_ident(n.nspname) || '.' || quote_ident(p.proname) || '(' || pg_catalog.pg_get_function_identity_arguments(p.oid) || ')',
+			       '', COALESCE(p.proacl, pg_catalog.acldefault('f', p.proowner)), p.proowner
+			FROM pg_catalog.pg_proc p
+			JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+			UNION ALL
+			SELECT CASE t.typtype WHEN 'd' THEN 'DOMAIN' ELSE 'TYPE' END,
+			       quote_ident(n.nspname) || '.' || quote_ident(t.typname), '',
+			       COALESCE(t.typacl, pg_catalog.acldefault('T', t.typowner)), t.typowner
+			FROM pg_catalog.pg_type t
+			JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+			LEFT JOIN pg_catalog.pg_class tc ON tc.oid = t.typrelid
+			LEFT JOIN pg_catalog.pg_type el ON el.oid = t.typelem AND el.typarray = t.oid
+			-- Row types of tables and implicit array types follow their table or element type
+			WHERE (tc.oid IS NULL OR tc.relkind = 'c') AND el.oid IS NULL
+		)
+		SELECT COALESCE(g.rolname, 'PUBLIC') as grantee, a.object_type, a.object, a.col,
+		       x.privilege_type, COALESCE(gr.rolname, '') as grantor, x.is_grantable
+		FROM acls a
+		CROSS JOIN LATERAL pg_catalog.aclexplode(a.acl) x
+		LEFT JOIN pg_catalog.pg_roles g ON g.oid = x.grantee
+		LEFT JOIN pg_catalog.pg_roles gr ON gr.oid = x.grantor
+		WHERE x.grantee <> a.owner
+		  AND split_part(a.object, '.', 1) NOT IN ('pg_catalog', 'information_schema')
+		  AND (a.object_type <> 'SCHEMA' OR a.object NOT LIKE 'pg\_%')
+		UNION ALL
+		SELECT COALESCE(g.rolname, 'PUBLIC'),
+		       'DEFAULT ' || CASE d.defaclobjtype
+		           WHEN 'r' THEN 'TABLES' WHEN 'S' THEN 'SEQUENCES' WHEN 'f' THEN 'FUNCTIONS'
+		           WHEN 'T' THEN 'TYPES' WHEN 'n' THEN 'SCHEMAS' END,
+		       COALESCE(quote_ident(n.nspname), ''), '',
+		       x.privilege_type, pg_catalog.pg_get_userbyid(d.defaclrole), x.is_grantable
+		FROM pg_catalog.pg_default_acl d
+		LEFT JOIN pg_catalog.pg_namespace n ON n.oid = d.defaclnamespace
+		CROSS JOIN LATERAL pg_catalog.aclexplode(d.defaclacl) x
+		LEFT JOIN pg_catalog.pg_roles g ON g.oid = x.grantee
+		ORDER BY 1, 2, 3, 4, 5`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var priv PrivilegeInfo
+		if err := rows.Scan(&priv.Grantee, &priv.ObjectType, &priv.Object, &priv.Column,
+			&priv.Privilege, &priv.Grantor, &priv.Grantable); err != nil {
+			continue
+		}
+		info.Privileges = append(info.Privileges, priv)
+	}
+	return rows.Err()
+}
+
+// grantStatements renders the privileges of a grantee as GRANT and
+// ALTER DEFAULT PRIVILEGES statements, combining privileges on the same object.
+// When several databases were collected, each statement names its database in a comment.
+func grantStatements(privileges []PrivilegeInfo, grantee string) []string {
+	type grantKey struct {
+		database, objectType, object, column, grantor string
+		grantable                                     bool
+	}
+	var keys []grantKey
+	privsByKey := make(map[grantKey][]string)
+	for _, priv := range privileges {
+		if priv.Grantee != grantee {
+			continue
+		}
+		key := grantKey{priv.Database, priv.ObjectType, priv.Object, priv.Column, "", priv.Grantable}
+		if strings.HasPrefix(priv.ObjectType, "DEFAULT ") {
+			key.grantor = priv.Grantor
+		}
+		if _, ok := privsByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		privsByKey[key] = append(privsByKey[key], priv.Privilege)
+	}
+
+	var statements []string
+	for _, key := range keys {
+		privs := privsByKey[key]
+		if key.column != "" {
+			// Column privileges: GRANT SELECT (col), UPDATE (col) ON TABLE ...
+			for i := range privs {
+				privs[i] = fmt.Sprintf("%s (%s)", privs[i], key.column)
+			}
+		}
+
+		var stmt string
+		if kind, ok := strings.CutPrefix(key.objectType, "DEFAULT "); ok {
+			stmt = "ALTER DEFAULT PRIVILEGES FOR ROLE " + key.grantor
+			if key.object != "" {
+				stmt += " IN SCHEMA " + key.object
 			}
-			grants = append(grants, fmt.Sprintf("DATABASE %s: %s", datname, datacl))
+			stmt += fmt.Sprintf(" GRANT %s ON %s TO %s", strings.Join(privs, ", "), kind, grantee)
+		} else {
+			stmt = fmt.Sprintf("GRANT %s ON %s %s TO %s", strings.Join(privs, ", "), key.objectType, key.object, grantee)
 		}
+		if key.grantable {
+			stmt += " WITH GRANT OPTION"
+		}
+		if key.database != "" {
+			stmt += " -- database " + key.database
+		}
+		statements = append(statements, stmt)
 	}
+	return statements
+}
 
-	return grants, nil
+// collectRoleGraph collects every role membership, including those of predefined
+// roles such as pg_write_all_data. Memberships without the INHERIT and SET options
+// give the member nothing and are skipped.
+func (c *PostgreSQLCollector) collectRoleGraph(info *DatabaseInfo) error {
+	// The INHERIT and SET options of a membership exist from PostgreSQL 16;
+	// before, the member's rolinherit applies and SET ROLE is always allowed
+	inherit, noSet, filter := "m.rolinherit", "false", ""
+	if c.version.IsAtLeast(16) {
+		inherit, noSet, filter = "a.inherit_option", "NOT a.set_option", "WHERE a.inherit_option OR a.set_option"
+	}
+	query := fmt.Sprintf(`
+		SELECT r.rolname, m.rolname, %s, %s, a.admin_option
+		FROM pg_catalog.pg_auth_members a
+		JOIN pg_catalog.pg_roles r ON r.oid = a.roleid
+		JOIN pg_catalog.pg_roles m ON m.oid = a.member
+		%s
+		ORDER BY 2, 1`, inherit, noSet, filter)
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	graph := &RoleGraph{}
+	for rows.Next() {
+		var edge RoleEdge
+		if err := rows.Scan(&edge.Role, &edge.Member, &edge.Inherit, &edge.NoSet, &edge.Admin); err != nil {
+			continue
+		}
+		graph.Edges = append(graph.Edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	info.RoleGraph = graph
+	return nil
 }
 
 func (c *PostgreSQLCollector) collectRoles(info *DatabaseInfo) error {
 	query := `
-		SELECT rolname, rolsuper, rolcreaterole, rolcreatedb, rolcanlogin
+		SELECT rolname, rolsuper, rolcreaterole, rolcreatedb, rolcanlogin, rolreplication, rolbypassrls
 		FROM pg_catalog.pg_roles
 		WHERE rolcanlogin = false
 		  AND rolname NOT LIKE 'pg_%'
@@ -474,15 +1505,21 @@ func (c *PostgreSQLCollector) collectRoles(info *DatabaseInfo) error {
 
 	for rows.Next() {
 		var rolName string
-		var rolSuper, rolCreateRole, rolCreateDB, rolCanLogin bool
+		var rolSuper, rolCreateRole, rolCreateDB, rolCanLogin, rolReplication, rolBypassRLS bool
 
-		if err := rows.Scan(&rolName, &rolSuper, &rolCreateRole, &rolCreateDB, &rolCanLogin); err != nil {
+		if err := rows.Scan(&rolName, &rolSuper, &rolCreateRole, &rolCreateDB, &rolCanLogin,
+			&rolReplication, &rolBypassRLS); err != nil {
 			continue
 		}
 
 		role := UserRole{
-			RoleName: rolName,
-			RoleHost: "", // PostgreSQL has no host concept for roles
+			RoleName:      rolName,
+			RoleHost:      "", // PostgreSQL has no host concept for roles
+			IsSuperuser:   rolSuper,
+			CanCreateDB:   rolCreateDB,
+			CanCreateRole: rolCreateRole,
+			Replication:   rolReplication,
+			BypassRLS:     rolBypassRLS,
 		}
 
 		// Build grants list from attributes
@@ -496,6 +1533,12 @@ func (c *PostgreSQLCollector) collectRoles(info *DatabaseInfo) error {
 		if rolCreateRole {
 			attrs = append(attrs, "CREATEROLE")
 		}
+		if rolReplication {
+			attrs = append(attrs, "REPLICATION")
+		}
+		if rolBypassRLS {
+			attrs = append(attrs, "BYPASSRLS")
+		}
 		if len(attrs) > 0 {
 			role.Grants = append(role.Grants, "Attributes: "+strings.Join(attrs, ", "))
 		}
@@ -519,8 +1562,23 @@ func (c *PostgreSQLCollector) collectRoles(info *DatabaseInfo) error {
 			}
 		}
 
+		// Object privileges
+		role.Grants = append(role.Grants, grantStatements(info.Privileges, rolName)...)
+
 		info.Roles = append(info.Roles, role)
 	}
+
+	// PUBLIC stands for every role. The privileges PostgreSQL grants it by default,
+	// such as EXECUTE on functions, are left out.
+	var public []PrivilegeInfo
+	for _, priv := range info.Privileges {
+		if priv.Grantee == "PUBLIC" && !isDefaultPublicPrivilege(priv) {
+			public = append(public, priv)
+		}
+	}
+	if grants := grantStatements(public, "PUBLIC"); len(grants) > 0 {
+		info.Roles = append(info.Roles, UserRole{RoleName: "PUBLIC", Grants: grants})
+	}
 	return nil
 }
 
@@ -544,7 +1602,8 @@ func (c *PostgreSQLCollector) collectRoutines(info *DatabaseInfo) error {
 		           WHEN 'i' THEN 'IMMUTABLE'
 		           WHEN 's' THEN 'STABLE'
 		           WHEN 'v' THEN 'VOLATILE'
-		       END as volatility
+		       END as volatility,
+		       COALESCE(pg_catalog.obj_description(p.oid, 'pg_proc'), '') as comment
 		FROM pg_catalog.pg_proc p
 		JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
 		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
@@ -563,7 +1622,7 @@ func (c *PostgreSQLCollector) collectRoutines(info *DatabaseInfo) error {
 		var returns, arguments, securityType, volatility string
 
 		if err := rows.Scan(&routine.Schema, &routine.Name, &routine.Type,
-			&routine.Definer, &definition, &returns, &arguments, &securityType, &volatility); err != nil {
+			&routine.Definer, &definition, &returns, &arguments, &securityType, &volatility, &routine.Comment); err != nil {
 			continue
 		}
 
@@ -581,6 +1640,65 @@ func (c *PostgreSQLCollector) collectRoutines(info *DatabaseInfo) error {
 	return nil
 }
 
+func (c *PostgreSQLCollector) collectEventTriggers(info *DatabaseInfo) error {
+	query := `
+		SELECT e.evtname, e.evtevent, pg_catalog.pg_get_userbyid(e.evtowner) as owner,
+		       e.evtenabled, COALESCE(array_to_string(e.evttags, ','), '') as tags,
+		       e.evtfoid::regproc::text as function
+		FROM pg_catalog.pg_event_trigger e
+		ORDER BY e.evtname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger EventTriggerInfo
+		var enabled, tags string
+
+		if err := rows.Scan(&trigger.Name, &trigger.Event, &trigger.Owner, &enabled, &tags, &trigger.Function); err != nil {
+			continue
+		}
+
+		switch enabled {
+		case "O":
+			trigger.Enabled = "ENABLED"
+		case "D":
+			trigger.Enabled = "DISABLED"
+		case "R":
+			trigger.Enabled = "ENABLE REPLICA"
+		case "A":
+			trigger.Enabled = "ENABLE ALWAYS"
+		}
+		if tags != "" {
+			trigger.Tags = strings.Split(tags, ",")
+		}
+
+		// Build DDL
+		ddl := fmt.Sprintf("CREATE EVENT TRIGGER %s ON %s", trigger.Name, trigger.Event)
+		if len(trigger.Tags) > 0 {
+			quoted := make([]string, len(trigger.Tags))
+			for i, tag := range trigger.Tags {
+				quoted[i] = "'" + tag + "'"
+			}
+			ddl += fmt.Sprintf("\n    WHEN TAG IN (%s)", strings.Join(quoted, ", "))
+		}
+		ddl += fmt.Sprintf("\n    EXECUTE FUNCTION %s();", trigger.Function)
+		switch enabled {
+		case "D":
+			ddl += fmt.Sprintf("\nALTER EVENT TRIGGER %s DISABLE;", trigger.Name)
+		case "R", "A":
+			ddl += fmt.Sprintf("\nALTER EVENT TRIGGER %s %s;", trigger.Name, trigger.Enabled)
+		}
+		trigger.DDL = ddl
+
+		info.EventTriggers = append(info.EventTriggers, trigger)
+	}
+	return nil
+}
+
 func (c *PostgreSQLCollector) collectVariables(info *DatabaseInfo) error {
 	var query string
 	if c.config.OnlyModifiedVariables {
@@ -642,8 +1760,10 @@ func (c *PostgreSQLCollector) collectVariables(info *DatabaseInfo) error {
 
 func (c *PostgreSQLCollector) collectExtensions(info *DatabaseInfo) error {
 	query := `
-		SELECT e.extname, e.extversion, COALESCE(c.description, '') as description
+		SELECT e.extname, e.extversion, COALESCE(a.default_version, '') as default_version,
+		       COALESCE(c.description, '') as description
 		FROM pg_catalog.pg_extension e
+		LEFT JOIN pg_catalog.pg_available_extensions a ON a.name = e.extname
 		LEFT JOIN pg_catalog.pg_description c ON c.objoid = e.oid
 		ORDER BY e.extname`
 
@@ -655,10 +1775,590 @@ func (c *PostgreSQLCollector) collectExtensions(info *DatabaseInfo) error {
 
 	for rows.Next() {
 		var ext Extension
-		if err := rows.Scan(&ext.Name, &ext.Version, &ext.Description); err != nil {
+		if err := rows.Scan(&ext.Name, &ext.Version, &ext.DefaultVersion, &ext.Description); err != nil {
 			continue
 		}
 		info.Extensions = append(info.Extensions, ext)
 	}
 	return nil
 }
+
+// collectTypes collects enum, composite, domain and range types and collations
+// defined in user schemas. Types created by extensions are skipped.
+func (c *PostgreSQLCollector) collectTypes(info *DatabaseInfo) error {
+	query := `
+		SELECT t.oid, n.nspname, t.typname, t.typtype,
+		       pg_catalog.pg_get_userbyid(t.typowner) as owner,
+		       COALESCE(pg_catalog.obj_description(t.oid, 'pg_type'), '') as comment
+		FROM pg_catalog.pg_type t
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_catalog.pg_class cl ON cl.oid = t.typrelid
+		WHERE t.typtype IN ('e', 'c', 'd', 'r')
+		  -- composite types of tables and views are not standalone types
+		  AND (t.typtype <> 'c' OR cl.relkind = 'c')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		  AND n.nspname NOT LIKE 'pg_temp_%'
+		  AND n.nspname NOT LIKE 'pg_toast_temp_%'
+		  AND NOT EXISTS (
+			SELECT 1 FROM pg_catalog.pg_depend d
+			WHERE d.classid = 'pg_catalog.pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e'
+		  )
+		ORDER BY n.nspname, t.typname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var oids []int64
+	var types []TypeInfo
+	for rows.Next() {
+		var oid int64
+		var typeInfo TypeInfo
+		var typtype string
+		if err := rows.Scan(&oid, &typeInfo.Schema, &typeInfo.Name, &typtype, &typeInfo.Owner, &typeInfo.Comment); err != nil {
+			continue
+		}
+
+		switch typtype {
+		case "e":
+			typeInfo.Kind = "ENUM"
+		case "c":
+			typeInfo.Kind = "COMPOSITE"
+		case "d":
+			typeInfo.Kind = "DOMAIN"
+		case "r":
+			typeInfo.Kind = "RANGE"
+		}
+		oids = append(oids, oid)
+		types = append(types, typeInfo)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for i := range types {
+		typeInfo := &types[i]
+		var err error
+		switch typeInfo.Kind {
+		case "ENUM":
+			err = c.getEnumType(typeInfo, oids[i])
+		case "COMPOSITE":
+			err = c.getCompositeType(typeInfo, oids[i])
+		case "DOMAIN":
+			err = c.getDomainType(typeInfo, oids[i])
+		case "RANGE":
+			err = c.getRangeType(typeInfo, oids[i])
+		}
+		if err != nil {
+			log.Printf("Warning: failed to get definition of type %s.%s: %v", typeInfo.Schema, typeInfo.Name, err)
+		}
+		if typeInfo.DDL != "" && typeInfo.Comment != "" {
+			objectType := "TYPE"
+			if typeInfo.Kind == "DOMAIN" {
+				objectType = "DOMAIN"
+			}
+			typeInfo.DDL += fmt.Sprintf("\nCOMMENT ON %s %s.%s IS %s;", objectType, typeInfo.Schema, typeInfo.Name, quoteLiteral(typeInfo.Comment))
+		}
+		info.Types = append(info.Types, *typeInfo)
+	}
+
+	return c.collectCollations(info)
+}
+
+func (c *PostgreSQLCollector) getEnumType(typeInfo *TypeInfo, oid int64) error {
+	rows, err := c.db.Query("SELECT enumlabel FROM pg_catalog.pg_enum WHERE enumtypid = $1 ORDER BY enumsortorder", oid)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var quoted []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			continue
+		}
+		typeInfo.Labels = append(typeInfo.Labels, label)
+		quoted = append(quoted, quoteLiteral(label))
+	}
+	typeInfo.DDL = fmt.Sprintf("CREATE TYPE %s.%s AS ENUM (%s);", typeInfo.Schema, typeInfo.Name, strings.Join(quoted, ", "))
+	return nil
+}
+
+func (c *PostgreSQLCollector) getCompositeType(typeInfo *TypeInfo, oid int64) error {
+	query := `
+		SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod)
+		FROM pg_catalog.pg_type t
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = t.typrelid
+		WHERE t.oid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`
+
+	rows, err := c.db.Query(query, oid)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var attributes []string
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			continue
+		}
+		typeInfo.Attributes = append(typeInfo.Attributes, name+" "+dataType)
+		attributes = append(attributes, "    "+name+" "+dataType)
+	}
+	typeInfo.DDL = fmt.Sprintf("CREATE TYPE %s.%s AS (\n%s\n);", typeInfo.Schema, typeInfo.Name, strings.Join(attributes, ",\n"))
+	return nil
+}
+
+func (c *PostgreSQLCollector) getDomainType(typeInfo *TypeInfo, oid int64) error {
+	query := `
+		SELECT pg_catalog.format_type(t.typbasetype, t.typtypmod), t.typnotnull, t.typdefault,
+		       CASE WHEN t.typcollation <> bt.typcollation THEN co.collname END as collation
+		FROM pg_catalog.pg_type t
+		JOIN pg_catalog.pg_type bt ON bt.oid = t.typbasetype
+		LEFT JOIN pg_catalog.pg_collation co ON co.oid = t.typcollation
+		WHERE t.oid = $1`
+
+	var notNull bool
+	var defaultValue, collation sql.NullString
+	if err := c.db.QueryRow(query, oid).Scan(&typeInfo.BaseType, &notNull, &defaultValue, &collation); err != nil {
+		return err
+	}
+
+	ddl := fmt.Sprintf("CREATE DOMAIN %s.%s AS %s", typeInfo.Schema, typeInfo.Name, typeInfo.BaseType)
+	if collation.Valid {
+		ddl += fmt.Sprintf("\n    COLLATE \"%s\"", collation.String)
+	}
+	if defaultValue.Valid {
+		ddl += "\n    DEFAULT " + defaultValue.String
+	}
+	if notNull {
+		ddl += "\n    NOT NULL"
+	}
+
+	rows, err := c.db.Query(`
+		SELECT conname, pg_catalog.pg_get_constraintdef(oid, true)
+		FROM pg_catalog.pg_constraint
+		WHERE contypid = $1 AND contype = 'c'
+		ORDER BY conname`, oid)
+	if err == nil {
+		defer rows.Close()
+		for rows.Next() {
+			var conName, conDef string
+			if err := rows.Scan(&conName, &conDef); err != nil {
+				continue
+			}
+			typeInfo.Constraints = append(typeInfo.Constraints, conDef)
+			ddl += fmt.Sprintf("\n    CONSTRAINT %s %s", conName, conDef)
+		}
+	}
+
+	typeInfo.DDL = ddl + ";"
+	return nil
+}
+
+func (c *PostgreSQLCollector) getRangeType(typeInfo *TypeInfo, oid int64) error {
+	query := `
+		SELECT pg_catalog.format_type(r.rngsubtype, NULL),
+		       CASE WHEN r.rngcanonical <> 0 THEN r.rngcanonical::regproc::text END as canonical,
+		       CASE WHEN r.rngsubdiff <> 0 THEN r.rngsubdiff::regproc::text END as subtype_diff,
+		       CASE WHEN NOT opc.opcdefault THEN opc.opcname END as opclass,
+		       co.collname as collation
+		FROM pg_catalog.pg_range r
+		JOIN pg_catalog.pg_opclass opc ON opc.oid = r.rngsubopc
+		LEFT JOIN pg_catalog.pg_collation co ON co.oid = r.rngcollation
+		WHERE r.rngtypid = $1`
+
+	var canonical, subtypeDiff, opclass, collation sql.NullString
+	if err := c.db.QueryRow(query, oid).Scan(&typeInfo.BaseType, &canonical, &subtypeDiff, &opclass, &collation); err != nil {
+		return err
+	}
+
+	options := []string{"    SUBTYPE = " + typeInfo.BaseType}
+	if opclass.Valid {
+		options = append(options, "    SUBTYPE_OPCLASS = "+opclass.String)
+	}
+	if collation.Valid && collation.String != "default" {
+		options = append(options, fmt.Sprintf("    COLLATION = \"%s\"", collation.String))
+	}
+	if canonical.Valid {
+		options = append(options, "    CANONICAL = "+canonical.String)
+	}
+	if subtypeDiff.Valid {
+		options = append(options, "    SUBTYPE_DIFF = "+subtypeDiff.String)
+	}
+	typeInfo.DDL = fmt.Sprintf("CREATE TYPE %s.%s AS RANGE (\n%s\n);", typeInfo.Schema, typeInfo.Name, strings.Join(options, ",\n"))
+	return nil
+}
+
+// collectCollations collects collations created in user schemas
+func (c *PostgreSQLCollector) collectCollations(info *DatabaseInfo) error {
+	// The locale column is colliculocale on PostgreSQL 16 and colllocale on 17+,
+	// so it is read through to_jsonb to work on both.
+	query := `
+		SELECT n.nspname, co.collname, pg_catalog.pg_get_userbyid(co.collowner) as owner,
+		       co.collprovider, co.collisdeterministic,
+		       COALESCE(co.collcollate, ''), COALESCE(co.collctype, ''),
+		       COALESCE(to_jsonb(co) ->> 'colllocale', to_jsonb(co) ->> 'colliculocale', '') as locale,
+		       COALESCE(pg_catalog.obj_description(co.oid, 'pg_collation'), '') as comment
+		FROM pg_catalog.pg_collation co
+		JOIN pg_catalog.pg_namespace n ON n.oid = co.collnamespace
+		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		  AND n.nspname NOT LIKE 'pg_temp_%'
+		  AND NOT EXISTS (
+			SELECT 1 FROM pg_catalog.pg_depend d
+			WHERE d.classid = 'pg_catalog.pg_collation'::regclass AND d.objid = co.oid AND d.deptype = 'e'
+		  )
+		ORDER BY n.nspname, co.collname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var typeInfo TypeInfo
+		var provider, collate, ctype, locale string
+		var deterministic bool
+		if err := rows.Scan(&typeInfo.Schema, &typeInfo.Name, &typeInfo.Owner, &provider, &deterministic,
+			&collate, &ctype, &locale, &typeInfo.Comment); err != nil {
+			continue
+		}
+		typeInfo.Kind = "COLLATION"
+
+		var options []string
+		switch provider {
+		case "i":
+			options = append(options, "provider = icu", "locale = "+quoteLiteral(locale))
+		case "b":
+			options = append(options, "provider = builtin", "locale = "+quoteLiteral(locale))
+		default:
+			options = append(options, "provider = libc", "lc_collate = "+quoteLiteral(collate), "lc_ctype = "+quoteLiteral(ctype))
+		}
+		if !deterministic {
+			options = append(options, "deterministic = false")
+		}
+		typeInfo.DDL = fmt.Sprintf("CREATE COLLATION %s.%s (%s);", typeInfo.Schema, typeInfo.Name, strings.Join(options, ", "))
+		if typeInfo.Comment != "" {
+			typeInfo.DDL += fmt.Sprintf("\nCOMMENT ON COLLATION %s.%s IS %s;", typeInfo.Schema, typeInfo.Name, quoteLiteral(typeInfo.Comment))
+		}
+
+		info.Types = append(info.Types, typeInfo)
+	}
+	return nil
+}
+
+// quoteLiteral quotes a string as a SQL literal
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// collectWorkload collects the top statements by total execution time from pg_stat_statements.
+// The view covers the whole cluster but only exists in the databases where the extension
+// is installed, so the database is taken from the collected extensions when possible.
+func (c *PostgreSQLCollector) collectWorkload(info *DatabaseInfo) error {
+	db := c.db
+	for _, ext := range info.Extensions {
+		if ext.Name != "pg_stat_statements" || ext.Database == "" {
+			continue
+		}
+		var current string
+		if err := c.db.QueryRow("SELECT current_database()").Scan(&current); err != nil {
+			return err
+		}
+		if ext.Database != current {
+			config := *c.config
+			config.Database = ext.Database
+			extDB, err := connectToPostgreSQL(&config)
+			if err != nil {
+				return fmt.Errorf("failed to connect to database %s: %v", ext.Database, err)
+			}
+			defer extDB.Close()
+			db = extDB
+		}
+		break
+	}
+
+	var schema string
+	err := db.QueryRow(`
+		SELECT quote_ident(n.nspname)
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = 'pg_stat_statements'`).Scan(&schema)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("pg_stat_statements is not installed")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Statements of other users show no queryid and "<insufficient privilege>"
+	// unless the current user has pg_read_all_stats
+	query := fmt.Sprintf(`
+		SELECT COALESCE(d.datname, ''), COALESCE(r.rolname, ''), COALESCE(s.queryid::text, ''),
+		       COALESCE(s.query, ''), s.calls, s.total_exec_time, s.mean_exec_time, s.rows,
+		       s.shared_blks_hit, s.shared_blks_read
+		FROM %s.pg_stat_statements s
+		LEFT JOIN pg_catalog.pg_database d ON d.oid = s.dbid
+		LEFT JOIN pg_catalog.pg_roles r ON r.oid = s.userid
+		WHERE ($2 = '' OR d.datname = $2)
+		ORDER BY s.total_exec_time DESC
+		LIMIT $1`, schema)
+
+	rows, err := db.Query(query, c.config.WorkloadLimit, c.config.Database)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var digest StatementDigest
+		if err := rows.Scan(&digest.Database, &digest.User, &digest.Digest, &digest.Query, &digest.Calls,
+			&digest.TotalTimeMs, &digest.MeanTimeMs, &digest.RowsReturned,
+			&digest.BlocksHit, &digest.BlocksRead); err != nil {
+			continue
+		}
+		info.Workload = append(info.Workload, digest)
+	}
+	return rows.Err()
+}
+
+// collectReplicationInfo collects streaming and logical replication status
+func (c *PostgreSQLCollector) collectReplicationInfo(info *DatabaseInfo) error {
+	// Publications and subscriptions are collected with the objects of each database
+	replicationInfo := info.ReplicationInfo
+	if replicationInfo == nil {
+		replicationInfo = &ReplicationInfo{}
+	}
+	replicationInfo.Role = "primary"
+
+	var inRecovery bool
+	if err := c.db.QueryRow("SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return err
+	}
+	if inRecovery {
+		replicationInfo.Role = "standby"
+	}
+	info.ReplicationInfo = replicationInfo
+
+	if err := c.getStandbys(replicationInfo); err != nil {
+		log.Printf("Warning: failed to collect pg_stat_replication: %v", err)
+	}
+	if err := c.getReplicationSlots(replicationInfo); err != nil {
+		log.Printf("Warning: failed to collect replication slots: %v", err)
+	}
+	if inRecovery {
+		if err := c.getWALReceiver(replicationInfo); err != nil {
+			log.Printf("Warning: failed to collect pg_stat_wal_receiver: %v", err)
+		}
+	}
+	return nil
+}
+
+// getStandbys gets the standbys connected to this server.
+// LSN and lag columns are only visible to superusers and members of pg_read_all_stats.
+func (c *PostgreSQLCollector) getStandbys(info *ReplicationInfo) error {
+	query := `
+		SELECT COALESCE(application_name, ''), COALESCE(client_addr::text, ''),
+		       COALESCE(state, ''), COALESCE(sync_state, ''),
+		       COALESCE(sent_lsn::text, ''), COALESCE(write_lsn::text, ''),
+		       COALESCE(flush_lsn::text, ''), COALESCE(replay_lsn::text, ''),
+		       COALESCE(write_lag::text, ''), COALESCE(flush_lag::text, ''), COALESCE(replay_lag::text, '')
+		FROM pg_catalog.pg_stat_replication
+		ORDER BY application_name, client_addr`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var standby StandbyStatus
+		if err := rows.Scan(&standby.ApplicationName, &standby.ClientAddr, &standby.State, &standby.SyncState,
+			&standby.SentLSN, &standby.WriteLSN, &standby.FlushLSN, &standby.ReplayLSN,
+			&standby.WriteLag, &standby.FlushLag, &standby.ReplayLag); err != nil {
+			continue
+		}
+		info.Standbys = append(info.Standbys, standby)
+	}
+	return rows.Err()
+}
+
+// getReplicationSlots gets replication slots and the WAL each of them retains
+func (c *PostgreSQLCollector) getReplicationSlots(info *ReplicationInfo) error {
+	query := `
+		SELECT slot_name, slot_type, COALESCE(plugin, ''), COALESCE(database, ''), active,
+		       COALESCE(wal_status, ''),
+		       COALESCE(pg_size_pretty(pg_wal_lsn_diff(
+		           CASE WHEN pg_is_in_recovery() THEN pg_last_wal_receive_lsn() ELSE pg_current_wal_lsn() END,
+		           restart_lsn)), '') as retained_wal
+		FROM pg_catalog.pg_replication_slots
+		ORDER BY slot_name`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot ReplicationSlot
+		if err := rows.Scan(&slot.Name, &slot.Type, &slot.Plugin, &slot.Database, &slot.Active,
+			&slot.WALStatus, &slot.RetainedWAL); err != nil {
+			continue
+		}
+		info.ReplicationSlots = append(info.ReplicationSlots, slot)
+	}
+	return rows.Err()
+}
+
+// getWALReceiver gets the WAL receiver status of a standby
+func (c *PostgreSQLCollector) getWALReceiver(info *ReplicationInfo) error {
+	query := `
+		SELECT status, COALESCE(sender_host, ''), COALESCE(sender_port, 0), COALESCE(slot_name, ''),
+		       COALESCE(flushed_lsn::text, ''), COALESCE(latest_end_lsn::text, ''),
+		       COALESCE(last_msg_receipt_time::text, '')
+		FROM pg_catalog.pg_stat_wal_receiver`
+
+	receiver := &WALReceiverStatus{}
+	err := c.db.QueryRow(query).Scan(&receiver.Status, &receiver.SenderHost, &receiver.SenderPort, &receiver.SlotName,
+		&receiver.FlushedLSN, &receiver.LatestEndLSN, &receiver.LastMsgReceiptTime)
+	if err == sql.ErrNoRows {
+		// Not streaming (e.g. restoring from archive)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	info.WALReceiver = receiver
+	return nil
+}
+
+// getPublications gets logical replication publications of the current database
+func (c *PostgreSQLCollector) getPublications(info *ReplicationInfo) error {
+	query := `
+		SELECT p.pubname, pg_catalog.pg_get_userbyid(p.pubowner), p.puballtables,
+		       p.pubinsert, p.pubupdate, p.pubdelete, p.pubtruncate,
+		       COALESCE((SELECT string_agg(quote_ident(t.schemaname) || '.' || quote_ident(t.tablename), ',' ORDER BY t.schemaname, t.tablename)
+		                 FROM pg_catalog.pg_publication_tables t WHERE t.pubname = p.pubname), '') as tables
+		FROM pg_catalog.pg_publication p
+		ORDER BY p.pubname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pub Publication
+		var pubInsert, pubUpdate, pubDelete, pubTruncate bool
+		var tables string
+		if err := rows.Scan(&pub.Name, &pub.Owner, &pub.AllTables, &pubInsert, &pubUpdate, &pubDelete, &pubTruncate, &tables); err != nil {
+			continue
+		}
+
+		if pubInsert {
+			pub.Operations = append(pub.Operations, "insert")
+		}
+		if pubUpdate {
+			pub.Operations = append(pub.Operations, "update")
+		}
+		if pubDelete {
+			pub.Operations = append(pub.Operations, "delete")
+		}
+		if pubTruncate {
+			pub.Operations = append(pub.Operations, "truncate")
+		}
+		if tables != "" {
+			pub.Tables = strings.Split(tables, ",")
+		}
+
+		ddl := "CREATE PUBLICATION " + pub.Name
+		if pub.AllTables {
+			ddl += " FOR ALL TABLES"
+		} else if len(pub.Tables) > 0 {
+			ddl += " FOR TABLE " + strings.Join(pub.Tables, ", ")
+		}
+		pub.DDL = ddl + fmt.Sprintf("\n    WITH (publish = '%s');", strings.Join(pub.Operations, ", "))
+
+		info.Publications = append(info.Publications, pub)
+	}
+	return rows.Err()
+}
+
+var conninfoPasswordRe = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// getSubscriptions gets logical replication subscriptions of the current database
+func (c *PostgreSQLCollector) getSubscriptions(info *ReplicationInfo) error {
+	query := `
+		SELECT s.subname, pg_catalog.pg_get_userbyid(s.subowner), s.subenabled,
+		       array_to_string(s.subpublications, ','), COALESCE(s.subslotname, '')
+		FROM pg_catalog.pg_subscription s
+		WHERE s.subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())
+		ORDER BY s.subname`
+
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var subscriptions []Subscription
+	for rows.Next() {
+		var sub Subscription
+		var publications string
+		if err := rows.Scan(&sub.Name, &sub.Owner, &sub.Enabled, &publications, &sub.SlotName); err != nil {
+			continue
+		}
+		if publications != "" {
+			sub.Publications = strings.Split(publications, ",")
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, sub := range subscriptions {
+		// subconninfo is only readable by superusers
+		conninfo := "..."
+		var raw string
+		// pg_subscription is shared by all databases, and subscription names are unique per database
+		conninfoQuery := `
+			SELECT subconninfo FROM pg_catalog.pg_subscription
+			WHERE subname = $1
+			  AND subdbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())`
+		if err := c.db.QueryRow(conninfoQuery, sub.Name).Scan(&raw); err == nil {
+			conninfo = conninfoPasswordRe.ReplaceAllString(raw, "${1}********")
+		}
+
+		ddl := fmt.Sprintf("CREATE SUBSCRIPTION %s\n    CONNECTION %s\n    PUBLICATION %s",
+			sub.Name, quoteLiteral(conninfo), strings.Join(sub.Publications, ", "))
+		var options []string
+		if !sub.Enabled {
+			options = append(options, "enabled = false")
+		}
+		switch {
+		case sub.SlotName == "":
+			options = append(options, "slot_name = NONE", "create_slot = false")
+		case sub.SlotName != sub.Name:
+			options = append(options, "slot_name = "+quoteLiteral(sub.SlotName))
+		}
+		if len(options) > 0 {
+			ddl += "\n    WITH (" + strings.Join(options, ", ") + ")"
+		}
+		sub.DDL = ddl + ";"
+
+		info.Subscriptions = append(info.Subscriptions, sub)
+	}
+	return nil
+}
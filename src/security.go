@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The security audit checks the collected user accounts, roles and privileges
+// for risky configurations. It reports Findings like the lint engine.
+
+// mysqlSystemAccounts are the locked accounts MySQL creates for internal use
+var mysqlSystemAccounts = map[string]bool{
+	"mysql.sys":        true,
+	"mysql.session":    true,
+	"mysql.infoschema": true,
+}
+
+// mysqlPasswordlessPlugins authenticate without a password, so an empty authentication_string is expected
+var mysqlPasswordlessPlugins = map[string]bool{
+	"auth_socket":            true,
+	"unix_socket":            true,
+	"authentication_windows": true,
+}
+
+// mysqlAdminPrivileges are global privileges that allow taking over the server
+var mysqlAdminPrivileges = []string{"ALL PRIVILEGES", "SUPER", "FILE", "SHUTDOWN", "CREATE USER", "SYSTEM_USER", "PROCESS"}
+
+var (
+	globalGrantRe    = regexp.MustCompile(`(?i)^GRANT\s+(.+?)\s+ON\s+\*\.\*\s+TO\s`)
+	grantOptionRe    = regexp.MustCompile(`(?i)\bWITH GRANT OPTION\b`)
+	usageOnlyGrantRe = regexp.MustCompile(`(?i)^GRANT\s+USAGE\s+ON\s+\*\.\*\s+TO\s`)
+)
+
+// AuditSecurity checks accounts, roles and privileges and returns the sorted findings.
+// now is used to detect expired PostgreSQL passwords.
+func AuditSecurity(info *DatabaseInfo, now time.Time) []Finding {
+	findings := []Finding{}
+	add := func(severity Severity, rule, object, message string) {
+		findings = append(findings, Finding{Severity: severity, Rule: rule, Object: object, Message: message})
+	}
+
+	if info.DBType == "postgres" {
+		auditPostgreSQL(info, now, add)
+	} else {
+		auditMySQL(info, add)
+	}
+	sortFindings(findings)
+	return findings
+}
+
+type addFinding func(severity Severity, rule, object, message string)
+
+func auditMySQL(info *DatabaseInfo, add addFinding) {
+	// MySQL 8.0 roles are locked rows of mysql.user and are listed as users too
+	roles := make(map[string]bool)
+	for _, role := range info.Roles {
+		roles[role.RoleName+"@"+role.RoleHost] = true
+	}
+
+	for _, user := range info.Users {
+		if mysqlSystemAccounts[user.User] {
+			continue
+		}
+		account := fmt.Sprintf("'%s'@'%s'", user.User, user.Host)
+		isRole := roles[user.User+"@"+user.Host]
+		locked := strings.EqualFold(user.AccountLocked, "Y")
+
+		if user.User == "" {
+			add(SeverityError, "anonymous-user", account, "anonymous account; anyone can connect from the matching hosts")
+		}
+		if user.Host == "%" && !isRole {
+			add(SeverityWarning, "any-host", account, "account can connect from any host")
+		}
+		if user.EmptyPassword && !locked && !isRole && !mysqlPasswordlessPlugins[user.Plugin] {
+			add(SeverityError, "empty-password", account, "account has no password")
+		}
+		if user.Plugin == "mysql_native_password" {
+			add(SeverityWarning, "native-password", account, "mysql_native_password is deprecated in 8.0 and disabled by default in 8.4; use caching_sha2_password")
+		}
+		if locked && !isRole && hasPrivileges(user.Grants) {
+			add(SeverityInfo, "locked-with-grants", account, "locked account still holds privileges")
+		}
+		auditMySQLGrants(account, user.Grants, add)
+	}
+
+	for _, role := range info.Roles {
+		auditMySQLGrants(fmt.Sprintf("role '%s'@'%s'", role.RoleName, role.RoleHost), role.Grants, add)
+	}
+}
+
+// auditMySQLGrants reports global administrative privileges and grant options
+func auditMySQLGrants(account string, grants []string, add addFinding) {
+	for _, grant := range grants {
+		if m := globalGrantRe.FindStringSubmatch(grant); m != nil {
+			var admin []string
+			privileges := "," + strings.ToUpper(m[1]) + ","
+			for _, privilege := range mysqlAdminPrivileges {
+				if strings.Contains(strings.ReplaceAll(privileges, ", ", ","), ","+privilege+",") {
+					admin = append(admin, privilege)
+				}
+			}
+			if len(admin) > 0 {
+				add(SeverityWarning, "global-admin-privileges", account,
+					fmt.Sprintf("holds %s ON *.*", strings.Join(admin, ", ")))
+			}
+		}
+		if grantOptionRe.MatchString(grant) {
+			add(SeverityWarning, "grant-option", account, "can grant its privileges to others: "+grant)
+		}
+	}
+}
+
+// hasPrivileges reports whether grants contain more than the implicit USAGE grant
+func hasPrivileges(grants []string) bool {
+	for _, grant := range grants {
+		if !usageOnlyGrantRe.MatchString(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+func auditPostgreSQL(info *DatabaseInfo, now time.Time, add addFinding) {
+	for _, user := range info.Users {
+		if user.IsSuperuser {
+			add(SeverityWarning, "superuser", user.User, "login role is a superuser and bypasses all permission checks")
+		}
+		if user.Replication {
+			add(SeverityWarning, "replication-role", user.User, "login role has REPLICATION and can stream all data")
+		}
+		if user.BypassRLS {
+			add(SeverityWarning, "bypass-rls", user.User, "login role has BYPASSRLS and ignores row-level security policies")
+		}
+		if validUntil, err := time.Parse(time.RFC3339Nano, user.ValidUntil); err == nil && validUntil.Before(now) {
+			add(SeverityWarning, "expired-password", user.User,
+				fmt.Sprintf("password expired at %s; remove the role if it is no longer used", validUntil.Format("2006-01-02 15:04:05")))
+		}
+	}
+
+	for _, role := range info.Roles {
+		if role.IsSuperuser {
+			add(SeverityWarning, "superuser", role.RoleName, "role is a superuser; members that SET ROLE to it bypass all permission checks")
+		}
+		if role.Replication {
+			add(SeverityWarning, "replication-role", role.RoleName, "role has REPLICATION")
+		}
+		if role.BypassRLS {
+			add(SeverityWarning, "bypass-rls", role.RoleName, "role has BYPASSRLS")
+		}
+	}
+
+	for _, priv := range info.Privileges {
+		if priv.Grantee != "PUBLIC" || isDefaultPublicPrivilege(priv) {
+			continue
+		}
+		object := priv.ObjectType + " " + priv.Object
+		if priv.Column != "" {
+			object += " (" + priv.Column + ")"
+		}
+		if priv.Database != "" {
+			object = priv.Database + ": " + object
+		}
+		add(SeverityWarning, "public-grant", object, fmt.Sprintf("%s is granted to PUBLIC (every role)", priv.Privilege))
+	}
+}
+
+// isDefaultPublicPrivilege reports whether a PUBLIC privilege is one PostgreSQL grants by default
+// (see acldefault in collectPrivileges)
+func isDefaultPublicPrivilege(priv PrivilegeInfo) bool {
+	switch priv.ObjectType {
+	case "DATABASE":
+		return priv.Privilege == "CONNECT" || priv.Privilege == "TEMPORARY"
+	case "SCHEMA":
+		return priv.Object == "public" && priv.Privilege == "USAGE"
+	case "FUNCTION", "PROCEDURE", "DEFAULT FUNCTIONS":
+		return priv.Privilege == "EXECUTE"
+	case "TYPE", "DOMAIN", "DEFAULT TYPES":
+		return priv.Privilege == "USAGE"
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// A snapshot is the JSON output document (see JSONDocument) written to a file.
+// It can be loaded later and rendered with any Formatter without a database connection.
+
+// SaveSnapshot writes the collected database information to a snapshot file
+func SaveSnapshot(info *DatabaseInfo, path string) error {
+	output, err := (&JSONFormatter{}).Format(info)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(output), 0644)
+}
+
+// LoadSnapshot reads database information from a snapshot file
+func LoadSnapshot(path string) (*DatabaseInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc JSONDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %v", path, err)
+	}
+	if doc.SchemaVersion == "" || doc.DatabaseInfo == nil {
+		return nil, fmt.Errorf("invalid snapshot %s: missing schema_version", path)
+	}
+	if schemaMajor(doc.SchemaVersion) != schemaMajor(JSONSchemaVersion) {
+		return nil, fmt.Errorf("snapshot %s has schema version %s, expected %s.x",
+			path, doc.SchemaVersion, schemaMajor(JSONSchemaVersion))
+	}
+	return doc.DatabaseInfo, nil
+}
+
+// schemaMajor returns the major part of a schema version such as "1.0"
+func schemaMajor(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
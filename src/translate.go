@@ -0,0 +1,704 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DDL translation rewrites the collected tables, indexes and views in the
+// dialect of the other engine. It is best effort and meant for prototyping:
+// what cannot be translated is dropped or kept as written, with an inline
+// comment explaining why. It works on the collected DDL, so it can run on a snapshot.
+
+// ddlLine is one column or constraint of a CREATE TABLE body with an optional comment
+type ddlLine struct {
+	def  string
+	note string
+}
+
+// translation collects the statements of a translated schema
+type translation struct {
+	out strings.Builder
+}
+
+// comment writes a SQL comment line
+func (t *translation) comment(format string, args ...interface{}) {
+	t.out.WriteString("-- " + fmt.Sprintf(format, args...) + "\n")
+}
+
+// statement writes a statement with an optional trailing comment
+func (t *translation) statement(stmt, note string) {
+	t.out.WriteString(stmt + ";")
+	if note != "" {
+		t.out.WriteString("  -- " + note)
+	}
+	t.out.WriteString("\n")
+}
+
+// createTable writes a CREATE TABLE statement with a comment after each line that has one
+func (t *translation) createTable(head string, lines []ddlLine, tail, note string) {
+	t.out.WriteString(head + " (\n")
+	for i, line := range lines {
+		t.out.WriteString("    " + line.def)
+		if i < len(lines)-1 {
+			t.out.WriteString(",")
+		}
+		if line.note != "" {
+			t.out.WriteString("  -- " + line.note)
+		}
+		t.out.WriteString("\n")
+	}
+	t.statement(")"+tail, note)
+}
+
+// TranslateDDL returns best-effort CREATE TABLE, CREATE INDEX and CREATE VIEW
+// statements for the collected schema in the dialect of target (postgres or mysql)
+func TranslateDDL(info *DatabaseInfo, target string) (string, error) {
+	target = strings.ToLower(strings.TrimSpace(target))
+	if target == "postgresql" {
+		target = "postgres"
+	}
+	if target != "postgres" && target != "mysql" {
+		return "", fmt.Errorf("unsupported translation target '%s'. Valid values: postgres, mysql", target)
+	}
+	if target == info.DBType {
+		return "", fmt.Errorf("the schema is already a %s schema", target)
+	}
+
+	source := "MySQL"
+	targetName := "PostgreSQL"
+	if info.DBType == "postgres" {
+		source, targetName = "PostgreSQL", "MySQL"
+	}
+	if info.ConnectionInfo != nil && info.ConnectionInfo.Version != "" {
+		source += " " + info.ConnectionInfo.Version
+	}
+
+	t := &translation{}
+	t.comment("Translated from %s to %s by databasemix.", source, targetName)
+	t.comment("Best effort: review the statements and the comments before use.")
+	if target == "postgres" {
+		translateToPostgreSQL(t, info)
+	} else {
+		translateToMySQL(t, info)
+	}
+	return t.out.String(), nil
+}
+
+// requote replaces the identifier quotes of one dialect with those of the other, outside string literals
+func requote(s string, from, to byte) string {
+	b := []byte(s)
+	inString := false
+	for i, c := range b {
+		switch {
+		case inString:
+			if c == '\'' {
+				inString = false
+			}
+		case c == '\'':
+			inString = true
+		case c == from:
+			b[i] = to
+		}
+	}
+	return string(b)
+}
+
+func quotePostgres(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteMySQL(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// schemaOrder returns the schemas of tables in the order they first appear
+func schemaOrder(tables []TableInfo) []string {
+	var schemas []string
+	seen := make(map[string]bool)
+	for _, table := range tables {
+		if !seen[table.Schema] {
+			seen[table.Schema] = true
+			schemas = append(schemas, table.Schema)
+		}
+	}
+	return schemas
+}
+
+// keyPart formats an index key part: a quoted column, or an expression as written
+func keyPart(part string, parsed *ddlTable, quote func(string) string, from, to byte) string {
+	if parsed.findColumn(part) != nil {
+		return quote(part)
+	}
+	return requote(part, from, to)
+}
+
+// MySQL to PostgreSQL
+
+func translateToPostgreSQL(t *translation, info *DatabaseInfo) {
+	tables := baseTables(info)
+
+	t.out.WriteString("\n")
+	for _, schema := range schemaOrder(info.Tables) {
+		t.statement("CREATE SCHEMA IF NOT EXISTS "+quotePostgres(schema), "")
+	}
+
+	for _, table := range tables {
+		t.out.WriteString("\n")
+		translateTableToPostgreSQL(t, table)
+	}
+
+	for _, table := range info.Tables {
+		if table.Type != "VIEW" {
+			continue
+		}
+		t.out.WriteString("\n")
+		var uses []string
+		query := viewQuery(table.DDL)
+		for _, syntax := range mysqlOnlySyntax {
+			if syntax.re.MatchString(query) {
+				uses = append(uses, syntax.syntax+" → "+syntax.replacement)
+			}
+		}
+		if len(uses) > 0 {
+			t.comment("MySQL-specific syntax to rewrite: %s", strings.Join(uses, ", "))
+		}
+		t.statement(fmt.Sprintf("CREATE VIEW %s.%s AS\n%s", quotePostgres(table.Schema), quotePostgres(table.Name),
+			strings.TrimSpace(strings.TrimSuffix(requote(query, '`', '"'), ";"))), "")
+	}
+}
+
+func translateTableToPostgreSQL(t *translation, table lintTable) {
+	parsed := table.structure
+	name := quotePostgres(table.info.Schema) + "." + quotePostgres(table.info.Name)
+	quote := func(part string) string { return keyPart(part, parsed, quotePostgres, '`', '"') }
+
+	var lines []ddlLine
+	for _, column := range parsed.Columns {
+		lines = append(lines, postgresColumn(column, table.info.AutoIncrement))
+	}
+	if pk := parsed.primaryKey(); pk != nil {
+		var parts []string
+		for _, part := range pk.Columns {
+			parts = append(parts, quote(part))
+		}
+		lines = append(lines, ddlLine{def: "PRIMARY KEY (" + strings.Join(parts, ", ") + ")"})
+	}
+	for _, fk := range parsed.ForeignKeys {
+		refTable := fk.RefTable
+		if !strings.Contains(refTable, ".") {
+			refTable = table.info.Schema + "." + refTable
+		}
+		refSchema, refName, _ := strings.Cut(refTable, ".")
+		var columns, refColumns []string
+		for _, column := range fk.Columns {
+			columns = append(columns, quotePostgres(column))
+		}
+		for _, column := range fk.RefColumns {
+			refColumns = append(refColumns, quotePostgres(column))
+		}
+		def := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s.%s (%s)", strings.Join(columns, ", "),
+			quotePostgres(refSchema), quotePostgres(refName), strings.Join(refColumns, ", "))
+		if fk.Name != "" {
+			def = "CONSTRAINT " + quotePostgres(fk.Name) + " " + def
+		}
+		if fk.OnDelete != "" {
+			def += " ON DELETE " + fk.OnDelete
+		}
+		if fk.OnUpdate != "" {
+			def += " ON UPDATE " + fk.OnUpdate
+		}
+		lines = append(lines, ddlLine{def: def})
+	}
+	for _, check := range parsed.Checks {
+		def := "CHECK " + requote(check.Expression, '`', '"')
+		if check.Name != "" {
+			def = "CONSTRAINT " + quotePostgres(check.Name) + " " + def
+		}
+		lines = append(lines, ddlLine{def: def, note: "check the expression for MySQL functions"})
+	}
+
+	note := ""
+	if strings.Contains(strings.ToUpper(table.info.DDL), "PARTITION BY") {
+		note = "MySQL partitioning not translated; use declarative partitioning"
+	}
+	t.createTable("CREATE TABLE "+name, lines, "", note)
+
+	prefixed := make(map[string]bool)
+	for _, m := range mysqlPrefixKeyRe.FindAllStringSubmatch(table.info.DDL, -1) {
+		prefixed[unquoteIdent(strings.TrimSpace(m[2]))] = true
+	}
+	for _, index := range parsed.Indexes {
+		if index.Primary {
+			continue
+		}
+		// Index names are unique per schema on PostgreSQL
+		indexName := quotePostgres(table.info.Name + "_" + index.Name)
+		var parts []string
+		for _, part := range index.Columns {
+			parts = append(parts, quote(part))
+		}
+
+		create, using, note := "CREATE INDEX", "", ""
+		if index.Unique {
+			create = "CREATE UNIQUE INDEX"
+		}
+		switch index.Method {
+		case "FULLTEXT":
+			var texts []string
+			for _, part := range index.Columns {
+				texts = append(texts, fmt.Sprintf("coalesce(%s, '')", quotePostgres(part)))
+			}
+			using = " USING gin"
+			parts = []string{fmt.Sprintf("to_tsvector('simple', %s)", strings.Join(texts, " || ' ' || "))}
+			note = "FULLTEXT: MATCH ... AGAINST becomes to_tsvector(...) @@ to_tsquery(...)"
+		case "SPATIAL":
+			using = " USING gist"
+			note = "SPATIAL: needs PostGIS"
+		}
+		if prefixed[index.Name] {
+			note = "column prefix dropped; the index covers the whole column"
+			if index.Unique {
+				note += ", so uniqueness is no longer limited to the prefix"
+			}
+		}
+		t.statement(fmt.Sprintf("%s %s ON %s%s (%s)", create, indexName, name, using, strings.Join(parts, ", ")), note)
+	}
+
+	if table.info.Comment != "" {
+		t.statement(fmt.Sprintf("COMMENT ON TABLE %s IS %s", name, quoteLiteral(table.info.Comment)), "")
+	}
+	for _, column := range parsed.Columns {
+		if column.Comment != "" {
+			t.statement(fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", name, quotePostgres(column.Name), quoteLiteral(column.Comment)), "")
+		}
+	}
+}
+
+var currentTimestampRe = regexp.MustCompile(`(?i)^(CURRENT_TIMESTAMP|NOW|LOCALTIMESTAMP)(\(\d*\))?$`)
+
+// postgresColumn translates a MySQL column definition
+func postgresColumn(column ddlColumn, autoIncrement int64) ddlLine {
+	pgType := postgresType(column)
+	typeName, args, unsigned := splitMySQLType(column.Type)
+	def := quotePostgres(column.Name) + " " + pgType
+	var notes []string
+
+	if column.AutoIncrement {
+		def += " GENERATED BY DEFAULT AS IDENTITY"
+		if autoIncrement > 1 {
+			def += fmt.Sprintf(" (START WITH %d)", autoIncrement)
+		}
+	}
+	if column.Generated != "" {
+		def += " GENERATED ALWAYS AS (" + requote(column.Generated, '`', '"') + ") STORED"
+		notes = append(notes, "generated column: check the expression")
+	}
+	if !column.Nullable {
+		def += " NOT NULL"
+	}
+
+	value := column.Default
+	switch {
+	case value == "" || strings.EqualFold(value, "NULL") || column.Generated != "":
+	case zeroDateRe.MatchString(value):
+		notes = append(notes, "zero date default dropped")
+	case currentTimestampRe.MatchString(value):
+		def += " DEFAULT CURRENT_TIMESTAMP"
+	case pgType == "boolean" && (value == "'0'" || value == "0"):
+		def += " DEFAULT false"
+	case pgType == "boolean" && (value == "'1'" || value == "1"):
+		def += " DEFAULT true"
+	case strings.HasPrefix(strings.ToLower(value), "b'"):
+		def += " DEFAULT B" + value[1:]
+	case strings.HasPrefix(value, "("):
+		def += " DEFAULT " + requote(value, '`', '"')
+		notes = append(notes, "expression default: check the functions")
+	default:
+		def += " DEFAULT " + value
+	}
+
+	switch {
+	case typeName == "enum":
+		def += fmt.Sprintf(" CHECK (%s IN (%s))", quotePostgres(column.Name), strings.Join(splitTopLevel(args, ','), ", "))
+	case typeName == "set":
+		notes = append(notes, "SET: values are not checked")
+	case unsigned && pgType != "boolean":
+		def += fmt.Sprintf(" CHECK (%s >= 0)", quotePostgres(column.Name))
+	case mysqlSpatialTypes[typeName]:
+		notes = append(notes, "needs PostGIS")
+	}
+	if column.OnUpdate != "" {
+		notes = append(notes, "ON UPDATE "+column.OnUpdate+" needs a BEFORE UPDATE trigger")
+	}
+	return ddlLine{def: def, note: strings.Join(notes, "; ")}
+}
+
+// PostgreSQL to MySQL
+
+var (
+	// pgCastRe matches a ::type cast, e.g. ::character varying or ::text[]
+	pgCastRe = regexp.MustCompile(`::(?:"[^"]*"|[A-Za-z_][\w.]*)(?:\s+(?:varying|precision|with(?:out)? time zone))*(?:\(\d+(?:,\s*\d+)?\))?(?:\[\])*`)
+
+	pgNumberRe = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+)
+
+// postgresOnlySyntax is PostgreSQL-specific SQL with its MySQL replacement
+var postgresOnlySyntax = []struct {
+	re          *regexp.Regexp
+	syntax      string
+	replacement string
+}{
+	{regexp.MustCompile(`\|\|`), "||", "CONCAT() (|| is OR in MySQL)"},
+	{regexp.MustCompile(`(?i)\bILIKE\b`), "ILIKE", "LIKE with a case-insensitive collation"},
+	{regexp.MustCompile(`(?i)\bDISTINCT\s+ON\b`), "DISTINCT ON", "ROW_NUMBER() OVER (...)"},
+	{regexp.MustCompile(`(?i)\bstring_agg\s*\(`), "string_agg()", "GROUP_CONCAT()"},
+	{regexp.MustCompile(`(?i)\bFILTER\s*\(\s*WHERE\b`), "FILTER (WHERE ...)", "CASE inside the aggregate"},
+	{regexp.MustCompile(`\s!?~\*?\s`), "~ regular expressions", "REGEXP"},
+	{regexp.MustCompile(`(?i)\bgenerate_series\s*\(`), "generate_series()", "a recursive CTE"},
+	{regexp.MustCompile(`(?i)\b(date_trunc|to_char|age)\s*\(`), "date_trunc()/to_char()/age()", "DATE_FORMAT() or TIMESTAMPDIFF()"},
+}
+
+func translateToMySQL(t *translation, info *DatabaseInfo) {
+	tables := baseTables(info)
+	types := make(map[string]TypeInfo)
+	for _, typ := range info.Types {
+		types[typ.Database+"."+typ.Schema+"."+typ.Name] = typ
+	}
+
+	current := ""
+	use := func(schema string) {
+		if schema == current {
+			return
+		}
+		current = schema
+		t.out.WriteString("\n")
+		t.statement("CREATE DATABASE IF NOT EXISTS "+quoteMySQL(schema), "")
+		t.statement("USE "+quoteMySQL(schema), "")
+	}
+
+	var sequences []string
+	for _, table := range info.Tables {
+		if table.Type == "SEQUENCE" {
+			sequences = append(sequences, table.Schema+"."+table.Name)
+		}
+	}
+
+	for _, table := range tables {
+		use(table.info.Schema)
+		t.out.WriteString("\n")
+		if table.info.PartitionOf != "" {
+			t.comment("Partition %s of %s not translated; its rows belong to the parent table", table.name(), table.info.PartitionOf)
+			continue
+		}
+		translateTableToMySQL(t, table, types)
+	}
+
+	for _, table := range info.Tables {
+		if table.Type != "VIEW" && table.Type != "MATERIALIZED VIEW" {
+			continue
+		}
+		use(table.Schema)
+		t.out.WriteString("\n")
+		query := viewQuery(table.DDL)
+		var uses []string
+		if pgCastRe.MatchString(query) {
+			uses = append(uses, ":: casts removed")
+		}
+		for _, syntax := range postgresOnlySyntax {
+			if syntax.re.MatchString(query) {
+				uses = append(uses, syntax.syntax+" → "+syntax.replacement)
+			}
+		}
+		if len(uses) > 0 {
+			t.comment("PostgreSQL-specific syntax to rewrite: %s", strings.Join(uses, ", "))
+		}
+		query = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(requote(pgCastRe.ReplaceAllString(query, ""), '"', '`')), ";"))
+		if table.Type == "MATERIALIZED VIEW" {
+			t.statement(fmt.Sprintf("CREATE TABLE %s AS\n%s", quoteMySQL(table.Name), query),
+				"materialized view: refresh by re-creating the table")
+			continue
+		}
+		t.statement(fmt.Sprintf("CREATE VIEW %s AS\n%s", quoteMySQL(table.Name), query), "")
+	}
+
+	if len(sequences) > 0 {
+		t.out.WriteString("\n")
+		t.comment("Sequences not translated, serial columns use AUTO_INCREMENT: %s", strings.Join(sequences, ", "))
+	}
+}
+
+func translateTableToMySQL(t *translation, table lintTable, types map[string]TypeInfo) {
+	parsed := table.structure
+	name := quoteMySQL(table.info.Name)
+
+	columnTypes := make(map[string]string)
+	var lines []ddlLine
+	for _, column := range parsed.Columns {
+		line, mysqlType := mysqlColumn(column, table.info, types)
+		columnTypes[column.Name] = mysqlType
+		lines = append(lines, line)
+	}
+
+	// TEXT and BLOB columns can only be indexed with a prefix length
+	keyParts := func(columns []string) (string, string) {
+		var parts, notes []string
+		for _, part := range columns {
+			mysqlType, ok := columnTypes[part]
+			switch {
+			case !ok:
+				parts = append(parts, "("+requote(pgCastRe.ReplaceAllString(part, ""), '"', '`')+")")
+				notes = append(notes, "functional key part needs MySQL 8.0.13")
+			case strings.HasSuffix(mysqlType, "text") || strings.HasSuffix(mysqlType, "blob"):
+				parts = append(parts, quoteMySQL(part)+"(255)")
+				notes = append(notes, part+" indexed with a 255 character prefix")
+			default:
+				parts = append(parts, quoteMySQL(part))
+			}
+		}
+		return strings.Join(parts, ", "), strings.Join(notes, "; ")
+	}
+
+	if pk := parsed.primaryKey(); pk != nil {
+		parts, note := keyParts(pk.Columns)
+		lines = append(lines, ddlLine{def: "PRIMARY KEY (" + parts + ")", note: note})
+	}
+	for _, fk := range parsed.ForeignKeys {
+		refTable := fk.RefTable
+		if !strings.Contains(refTable, ".") {
+			refTable = table.info.Schema + "." + refTable
+		}
+		refSchema, refName, _ := strings.Cut(refTable, ".")
+		var columns, refColumns []string
+		for _, column := range fk.Columns {
+			columns = append(columns, quoteMySQL(column))
+		}
+		for _, column := range fk.RefColumns {
+			refColumns = append(refColumns, quoteMySQL(column))
+		}
+		def := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s.%s (%s)", strings.Join(columns, ", "),
+			quoteMySQL(refSchema), quoteMySQL(refName), strings.Join(refColumns, ", "))
+		if fk.Name != "" {
+			def = "CONSTRAINT " + quoteMySQL(fk.Name) + " " + def
+		}
+		if fk.OnDelete != "" {
+			def += " ON DELETE " + fk.OnDelete
+		}
+		if fk.OnUpdate != "" {
+			def += " ON UPDATE " + fk.OnUpdate
+		}
+		lines = append(lines, ddlLine{def: def})
+	}
+	for _, check := range parsed.Checks {
+		def := "CHECK " + requote(pgCastRe.ReplaceAllString(check.Expression, ""), '"', '`')
+		if check.Name != "" {
+			def = "CONSTRAINT " + quoteMySQL(check.Name) + " " + def
+		}
+		lines = append(lines, ddlLine{def: def, note: "check the expression for PostgreSQL functions"})
+	}
+
+	var notes []string
+	if table.info.Unlogged {
+		notes = append(notes, "UNLOGGED not translated")
+	}
+	if table.info.PartitionKey != "" {
+		notes = append(notes, "PARTITION BY "+table.info.PartitionKey+" not translated")
+	}
+	if table.info.RowSecurity {
+		notes = append(notes, "row-level security policies not translated")
+	}
+	tail := " ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
+	if table.info.Comment != "" {
+		tail += " COMMENT=" + quoteLiteral(table.info.Comment)
+	}
+	t.createTable("CREATE TABLE "+name, lines, tail, strings.Join(notes, "; "))
+
+	for _, index := range parsed.Indexes {
+		if index.Primary {
+			continue
+		}
+		parts, note := keyParts(index.Columns)
+		stmt := fmt.Sprintf("CREATE INDEX %s ON %s (%s)", quoteMySQL(index.Name), name, parts)
+		if index.Unique {
+			stmt = "CREATE UNIQUE " + strings.TrimPrefix(stmt, "CREATE ")
+		}
+		switch index.Method {
+		case "GIN", "GIST", "SPGIST", "BRIN":
+			t.comment("%s;  -- %s index not translated", stmt, strings.ToLower(index.Method))
+			continue
+		}
+		if index.Partial {
+			note = strings.TrimPrefix(note+"; WHERE clause of the partial index dropped", "; ")
+		}
+		t.statement(stmt, note)
+	}
+}
+
+// mysqlColumn translates a PostgreSQL column definition and returns it with its MySQL type
+func mysqlColumn(column ddlColumn, table *TableInfo, types map[string]TypeInfo) (ddlLine, string) {
+	mysqlType, note := mysqlTypeFor(column.Type, table, types)
+	def := quoteMySQL(column.Name) + " " + mysqlType
+	var notes []string
+	if note != "" {
+		notes = append(notes, note)
+	}
+
+	if column.Generated != "" {
+		def += " GENERATED ALWAYS AS (" + requote(pgCastRe.ReplaceAllString(column.Generated, ""), '"', '`') + ") STORED"
+		notes = append(notes, "generated column: check the expression")
+	}
+	if !column.Nullable {
+		def += " NOT NULL"
+	}
+
+	value := strings.TrimSpace(pgCastRe.ReplaceAllString(column.Default, ""))
+	lower := strings.ToLower(value)
+	// TEXT, BLOB and JSON columns only take expression defaults
+	literal := func(v string) string {
+		if strings.HasSuffix(mysqlType, "text") || strings.HasSuffix(mysqlType, "blob") || mysqlType == "json" {
+			return "(" + v + ")"
+		}
+		return v
+	}
+	switch {
+	case column.Identity != "" || strings.HasPrefix(lower, "nextval("):
+		def += " AUTO_INCREMENT"
+	case value == "" || lower == "null" || column.Generated != "":
+	case currentTimestampRe.MatchString(value) || lower == "statement_timestamp()" || lower == "transaction_timestamp()":
+		precision := ""
+		if _, args, _ := splitMySQLType(mysqlType); args != "" {
+			precision = "(" + args + ")"
+		}
+		def += " DEFAULT CURRENT_TIMESTAMP" + precision
+	case lower == "current_date":
+		def += " DEFAULT (CURRENT_DATE)"
+	case lower == "true":
+		def += " DEFAULT 1"
+	case lower == "false":
+		def += " DEFAULT 0"
+	case lower == "gen_random_uuid()" || lower == "uuid_generate_v4()":
+		def += " DEFAULT (uuid())"
+	case strings.HasPrefix(value, "'") || pgNumberRe.MatchString(value):
+		def += " DEFAULT " + literal(value)
+	default:
+		notes = append(notes, "DEFAULT "+column.Default+" not translated")
+	}
+	return ddlLine{def: def, note: strings.Join(notes, "; ")}, mysqlType
+}
+
+// pgTypeArgsRe matches the modifiers of a PostgreSQL type, e.g. (10,2)
+var pgTypeArgsRe = regexp.MustCompile(`\s*\(([\d,\s]+)\)`)
+
+// mysqlTypeFor maps a PostgreSQL column type to MySQL, with a note when the mapping loses something
+func mysqlTypeFor(pgType string, table *TableInfo, types map[string]TypeInfo) (string, string) {
+	args := ""
+	if m := pgTypeArgsRe.FindStringSubmatch(pgType); m != nil {
+		args = strings.ReplaceAll(m[1], " ", "")
+	}
+	name := strings.Join(strings.Fields(pgTypeArgsRe.ReplaceAllString(pgType, "")), " ")
+	withArgs := func(mysqlName, fallback string) string {
+		if args != "" {
+			return mysqlName + "(" + args + ")"
+		}
+		return fallback
+	}
+
+	switch name {
+	case "smallint", "int2":
+		return "smallint", ""
+	case "integer", "int", "int4":
+		return "int", ""
+	case "bigint", "int8":
+		return "bigint", ""
+	case "boolean", "bool":
+		return "tinyint(1)", ""
+	case "real", "float4":
+		return "float", ""
+	case "double precision", "float8":
+		return "double", ""
+	case "numeric", "decimal":
+		if args == "" {
+			return "decimal(65,30)", "unconstrained numeric"
+		}
+		return "decimal(" + args + ")", ""
+	case "money":
+		return "decimal(19,2)", ""
+	case "varchar", "character varying":
+		return withArgs("varchar", "longtext"), ""
+	case "char", "character", "bpchar":
+		return withArgs("char", "char(1)"), ""
+	case "text", "xml":
+		return "longtext", ""
+	case "bytea":
+		return "longblob", ""
+	case "json", "jsonb":
+		return "json", ""
+	case "uuid":
+		return "char(36)", ""
+	case "date":
+		return "date", ""
+	case "timestamp", "timestamp without time zone":
+		return withArgs("datetime", "datetime(6)"), ""
+	case "timestamp with time zone", "timestamptz":
+		return withArgs("timestamp", "timestamp(6)"), "stored in UTC without the offset; TIMESTAMP ends in 2038"
+	case "time", "time without time zone":
+		return withArgs("time", "time(6)"), ""
+	case "time with time zone", "timetz":
+		return withArgs("time", "time(6)"), "time zone dropped"
+	case "interval":
+		return "varchar(64)", "no interval type"
+	case "inet", "cidr":
+		return "varchar(43)", ""
+	case "macaddr":
+		return "varchar(17)", ""
+	case "bit":
+		return withArgs("bit", "bit(1)"), ""
+	case "bit varying", "varbit":
+		return "varbinary(255)", "bit varying"
+	case "tsvector", "tsquery":
+		return "longtext", "full-text search type; use a FULLTEXT index"
+	case "oid":
+		return "int unsigned", ""
+	}
+
+	if strings.HasPrefix(name, "_") || strings.HasSuffix(name, "[]") {
+		return "json", "array stored as JSON"
+	}
+	if typ, found := findPostgresType(name, table, types); found {
+		switch typ.Kind {
+		case "ENUM":
+			var labels []string
+			for _, label := range typ.Labels {
+				labels = append(labels, quoteLiteral(label))
+			}
+			return "enum(" + strings.Join(labels, ",") + ")", ""
+		case "DOMAIN":
+			mysqlName, note := mysqlTypeFor(typ.BaseType, table, types)
+			return mysqlName, strings.TrimPrefix(note+"; domain "+name+" constraints dropped", "; ")
+		case "COMPOSITE":
+			return "json", "composite type " + name + " stored as JSON"
+		}
+	}
+	return "longtext", "no MySQL type for " + pgType
+}
+
+// findPostgresType looks up a user-defined type used by a column of table. format_type
+// leaves the types of schemas in the search_path unqualified, so an unqualified name is
+// looked up in the table's schema and then in public.
+func findPostgresType(name string, table *TableInfo, types map[string]TypeInfo) (TypeInfo, bool) {
+	schemas := []string{table.Schema, "public"}
+	if schema, typeName, ok := strings.Cut(name, "."); ok {
+		schemas, name = []string{unquoteIdent(schema)}, typeName
+	}
+	name = unquoteIdent(name)
+	for _, schema := range schemas {
+		// Types are labelled with their database only when several databases are collected
+		for _, database := range []string{table.Database, ""} {
+			if typ, found := types[database+"."+schema+"."+name]; found {
+				return typ, true
+			}
+		}
+	}
+	return TypeInfo{}, false
+}
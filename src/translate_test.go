@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslateDDLEnumColumn(t *testing.T) {
+	for _, database := range []string{"shop", ""} {
+		info := &DatabaseInfo{
+			DBType: "postgres",
+			Tables: []TableInfo{{
+				Database: "shop",
+				Schema:   "public",
+				Name:     "people",
+				Type:     "BASE TABLE",
+				Columns: []ColumnInfo{
+					{Name: "id", Position: 1, Type: "integer"},
+					{Name: "current_mood", Position: 2, Type: "mood", Nullable: true},
+					{Name: "usual_mood", Position: 3, Type: "public.mood", Nullable: true},
+				},
+				Indexes: []IndexInfo{{Name: "people_pkey", Columns: []string{"id"}, Primary: true, Unique: true, Method: "btree"}},
+			}},
+			// Types are labelled with their database only when all databases are collected
+			Types: []TypeInfo{{Database: database, Schema: "public", Name: "mood", Kind: "ENUM", Labels: []string{"sad", "ok", "happy"}}},
+		}
+
+		ddl, err := TranslateDDL(info, "mysql")
+		if err != nil {
+			t.Fatalf("TranslateDDL: %v", err)
+		}
+		for _, column := range []string{"`current_mood`", "`usual_mood`"} {
+			if !strings.Contains(ddl, column+" enum('sad','ok','happy')") {
+				t.Errorf("type database %q: %s is not an enum:\n%s", database, column, ddl)
+			}
+		}
+		if strings.Contains(ddl, "no MySQL type for") {
+			t.Errorf("type database %q: unexpected fallback type:\n%s", database, ddl)
+		}
+	}
+}
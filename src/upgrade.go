@@ -0,0 +1,867 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The upgrade check reports what stands in the way of a major version upgrade.
+// It works on the collected information, so it can run on a snapshot.
+// Error findings are blockers, warnings need attention before or after the upgrade.
+
+// UpgradeReport is the result of -upgrade-check
+type UpgradeReport struct {
+	Source   string    `json:"source"` // server version
+	Target   string    `json:"target"`
+	Findings []Finding `json:"findings"`
+}
+
+// CheckUpgrade checks the collected information against the changes up to target
+func CheckUpgrade(info *DatabaseInfo, target string) (*UpgradeReport, error) {
+	if info.ConnectionInfo == nil || info.ConnectionInfo.Version == "" {
+		return nil, fmt.Errorf("server version is unknown")
+	}
+	switch info.DBType {
+	case "mysql":
+		return checkMySQLUpgrade(info, target)
+	case "postgres":
+		return checkPostgreSQLUpgrade(info, target)
+	default:
+		return nil, fmt.Errorf("upgrade check is not supported for %s", info.DBType)
+	}
+}
+
+// mysqlUpgradeSteps are the supported major upgrades, in order.
+// Each step is identified by its target version.
+var mysqlUpgradeSteps = []struct {
+	from, to string
+}{
+	{"5.7", "8.0"},
+	{"8.0", "8.4"},
+}
+
+// upgradeResult is what a rule reports for one step
+type upgradeResult struct {
+	severity Severity
+	object   string
+	message  string
+}
+
+// upgradeRule is one check; check is called once per upgrade step
+type upgradeRule struct {
+	id    string
+	check func(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult
+}
+
+// runUpgradeRules runs the rules for each step. A rule can report an object
+// for several steps; the most severe finding, or else the one of the later step, is kept.
+func runUpgradeRules(info *DatabaseInfo, rules []upgradeRule, steps []string, findings []Finding) []Finding {
+	tables := baseTables(info)
+	index := make(map[[2]string]int)
+	for _, step := range steps {
+		for _, rule := range rules {
+			for _, result := range rule.check(info, tables, step) {
+				finding := Finding{Severity: result.severity, Rule: rule.id, Object: result.object, Message: result.message}
+				key := [2]string{rule.id, result.object}
+				if i, ok := index[key]; ok {
+					if finding.Severity.rank() >= findings[i].Severity.rank() {
+						findings[i] = finding
+					}
+					continue
+				}
+				index[key] = len(findings)
+				findings = append(findings, finding)
+			}
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+var mysqlUpgradeRules = []upgradeRule{
+	{"removed-variable", checkRemovedVariables},
+	{"removed-sql-mode", checkRemovedSQLModes},
+	{"native-password", checkNativePasswordUpgrade},
+	{"removed-plugin", checkUpgradePlugins},
+	{"reserved-word", checkReservedWords},
+	{"removed-function", checkRemovedFunctions},
+	{"group-by-sorting", checkGroupBySorting},
+	{"non-native-partitioning", checkNonNativePartitioning},
+	{"zero-date-default", checkZeroDateDefaults},
+	{"utf8mb3", checkUTF8MB3},
+}
+
+func checkMySQLUpgrade(info *DatabaseInfo, target string) (*UpgradeReport, error) {
+	version, err := ParseMySQLVersion(info.ConnectionInfo.Version)
+	if err != nil {
+		return nil, err
+	}
+	if version.IsMariaDB() {
+		return nil, fmt.Errorf("upgrade check does not support MariaDB")
+	}
+	source := fmt.Sprintf("%d.%d", version.Major, version.Minor)
+
+	// The steps between the source and the target; 8.1 to 8.3 are innovation
+	// releases on the way to 8.4 and are checked as the 8.0 to 8.4 step
+	var steps []string
+	targetKnown := false
+	for _, step := range mysqlUpgradeSteps {
+		if step.to == target {
+			targetKnown = true
+		}
+		if compareVersions(source, step.to) < 0 && compareVersions(step.to, target) <= 0 {
+			steps = append(steps, step.to)
+		}
+	}
+	if !targetKnown {
+		return nil, fmt.Errorf("unsupported MySQL upgrade target '%s'. Valid values: 8.0, 8.4", target)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("server is already at MySQL %s", version.FullVersion)
+	}
+
+	findings := []Finding{}
+	if len(steps) > 1 {
+		findings = append(findings, Finding{
+			Severity: SeverityError,
+			Rule:     "upgrade-path",
+			Object:   "server",
+			Message: fmt.Sprintf("upgrading from %s to %s directly is not supported; upgrade to %s first",
+				source, target, steps[0]),
+		})
+	}
+
+	findings = runUpgradeRules(info, mysqlUpgradeRules, steps, findings)
+	return &UpgradeReport{Source: version.FullVersion, Target: target, Findings: findings}, nil
+}
+
+// compareVersions compares dotted version numbers such as 8.0 and 8.4
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			fmt.Sscanf(as[i], "%d", &x)
+		}
+		if i < len(bs) {
+			fmt.Sscanf(bs[i], "%d", &y)
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// mysqlRemovedVariables are the system variables removed by each step, with their replacement.
+// A server does not start when one of them is set in its configuration.
+var mysqlRemovedVariables = map[string]map[string]string{
+	"8.0": {
+		"date_format":                           "",
+		"datetime_format":                       "",
+		"time_format":                           "",
+		"have_crypt":                            "",
+		"ignore_builtin_innodb":                 "",
+		"ignore_db_dirs":                        "",
+		"innodb_checksums":                      "innodb_checksum_algorithm",
+		"innodb_file_format":                    "",
+		"innodb_file_format_check":              "",
+		"innodb_file_format_max":                "",
+		"innodb_large_prefix":                   "",
+		"innodb_locks_unsafe_for_binlog":        "READ COMMITTED isolation",
+		"innodb_stats_sample_pages":             "innodb_stats_transient_sample_pages",
+		"innodb_support_xa":                     "",
+		"innodb_undo_logs":                      "innodb_rollback_segments",
+		"log_builtin_as_identified_by_password": "",
+		"log_warnings":                          "log_error_verbosity",
+		"max_tmp_tables":                        "",
+		"metadata_locks_cache_size":             "",
+		"metadata_locks_hash_instances":         "",
+		"multi_range_count":                     "",
+		"old_passwords":                         "",
+		"query_cache_limit":                     "",
+		"query_cache_min_res_unit":              "",
+		"query_cache_size":                      "",
+		"query_cache_type":                      "",
+		"query_cache_wlock_invalidate":          "",
+		"secure_auth":                           "",
+		"show_compatibility_56":                 "",
+		"sync_frm":                              "",
+		"tx_isolation":                          "transaction_isolation",
+		"tx_read_only":                          "transaction_read_only",
+	},
+	"8.4": {
+		"avoid_temporal_upgrade":                 "",
+		"binlog_transaction_dependency_tracking": "",
+		"default_authentication_plugin":          "authentication_policy",
+		"expire_logs_days":                       "binlog_expire_logs_seconds",
+		"group_replication_ip_whitelist":         "group_replication_ip_allowlist",
+		"innodb_api_bk_commit_interval":          "",
+		"innodb_api_disable_rowlock":             "",
+		"innodb_api_enable_binlog":               "",
+		"innodb_api_enable_mdl":                  "",
+		"innodb_api_trx_level":                   "",
+		"keyring_encrypted_file_data":            "component_keyring_encrypted_file",
+		"keyring_encrypted_file_password":        "component_keyring_encrypted_file",
+		"keyring_file_data":                      "component_keyring_file",
+		"log_bin_use_v1_row_events":              "",
+		"master_info_repository":                 "",
+		"new":                                    "",
+		"old":                                    "",
+		"relay_log_info_repository":              "",
+		"show_old_temporals":                     "",
+		"skip_host_cache":                        "host_cache_size=0",
+		"slave_rows_search_algorithms":           "",
+		"transaction_write_set_extraction":       "",
+	},
+}
+
+func checkRemovedVariables(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, variable := range info.Variables {
+		if !variable.IsModified {
+			continue
+		}
+		replacement, removed := mysqlRemovedVariables[step][strings.ToLower(variable.Name)]
+		if !removed {
+			continue
+		}
+		message := fmt.Sprintf("removed in %s but set", step)
+		if variable.Source != "" {
+			message += " (" + variable.Source + ")"
+		}
+		message += "; remove it from the configuration"
+		if replacement != "" {
+			message += ", use " + replacement + " instead"
+		}
+		results = append(results, upgradeResult{SeverityError, variable.Name, message})
+	}
+	return results
+}
+
+// mysqlRemovedSQLModes are the sql_mode values removed in 8.0
+var mysqlRemovedSQLModes = []string{
+	"DB2", "MAXDB", "MSSQL", "MYSQL323", "MYSQL40", "NO_AUTO_CREATE_USER",
+	"NO_FIELD_OPTIONS", "NO_KEY_OPTIONS", "NO_TABLE_OPTIONS", "ORACLE", "POSTGRESQL",
+}
+
+// removedSQLModes returns the removed modes in a sql_mode value
+func removedSQLModes(sqlMode string) []string {
+	var removed []string
+	for _, mode := range strings.Split(strings.ToUpper(sqlMode), ",") {
+		for _, r := range mysqlRemovedSQLModes {
+			if strings.TrimSpace(mode) == r {
+				removed = append(removed, r)
+			}
+		}
+	}
+	return removed
+}
+
+func checkRemovedSQLModes(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "8.0" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, variable := range info.Variables {
+		if strings.EqualFold(variable.Name, "sql_mode") && variable.IsModified {
+			if removed := removedSQLModes(variable.CurrentValue); len(removed) > 0 {
+				results = append(results, upgradeResult{SeverityError, variable.Name,
+					fmt.Sprintf("sql_mode is set with %s, removed in 8.0; the server does not start with them", strings.Join(removed, ", "))})
+			}
+		}
+	}
+	// Stored objects keep the sql_mode they were created with; the upgrade clears removed modes
+	for _, trigger := range info.Triggers {
+		if removed := removedSQLModes(trigger.SQLMode); len(removed) > 0 {
+			results = append(results, upgradeResult{SeverityWarning, "trigger " + trigger.Schema + "." + trigger.Name,
+				fmt.Sprintf("created with sql_mode %s, removed in 8.0", strings.Join(removed, ", "))})
+		}
+	}
+	for _, event := range info.Events {
+		if removed := removedSQLModes(event.SQLMode); len(removed) > 0 {
+			results = append(results, upgradeResult{SeverityWarning, "event " + event.Schema + "." + event.Name,
+				fmt.Sprintf("created with sql_mode %s, removed in 8.0", strings.Join(removed, ", "))})
+		}
+	}
+	return results
+}
+
+func checkNativePasswordUpgrade(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	roles := make(map[string]bool)
+	for _, role := range info.Roles {
+		roles[role.RoleName+"@"+role.RoleHost] = true
+	}
+	var results []upgradeResult
+	for _, user := range info.Users {
+		if user.Plugin != "mysql_native_password" || mysqlSystemAccounts[user.User] || roles[user.User+"@"+user.Host] {
+			continue
+		}
+		account := fmt.Sprintf("'%s'@'%s'", user.User, user.Host)
+		if step == "8.4" {
+			results = append(results, upgradeResult{SeverityError, account,
+				"uses mysql_native_password, which is disabled by default in 8.4; switch to caching_sha2_password or start with mysql_native_password=ON"})
+		} else {
+			results = append(results, upgradeResult{SeverityWarning, account,
+				"uses mysql_native_password, which is deprecated in 8.0; new accounts default to caching_sha2_password, so check client support"})
+		}
+	}
+	return results
+}
+
+// mysqlRemovedPlugins are the plugins removed (error) or deprecated (warning) by each step
+var mysqlRemovedPlugins = map[string]map[string]upgradeResult{
+	"8.0": {
+		"validate_password":  {SeverityWarning, "", "the plugin is deprecated in 8.0; use the validate_password component"},
+		"mysql_old_password": {SeverityError, "", "removed in 8.0"},
+	},
+	"8.4": {
+		"authentication_fido":    {SeverityError, "", "removed in 8.4; use authentication_webauthn"},
+		"daemon_memcached":       {SeverityError, "", "the memcached plugin was removed in 8.3"},
+		"keyring_file":           {SeverityError, "", "removed in 8.4; use component_keyring_file"},
+		"keyring_encrypted_file": {SeverityError, "", "removed in 8.4; use component_keyring_encrypted_file"},
+		"keyring_oci":            {SeverityError, "", "removed in 8.4; use component_keyring_oci"},
+		"sha256_password":        {SeverityWarning, "", "deprecated; use caching_sha2_password"},
+		"validate_password":      {SeverityWarning, "", "the plugin is deprecated; use the validate_password component"},
+	},
+}
+
+func checkUpgradePlugins(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, plugin := range info.Plugins {
+		if !strings.EqualFold(plugin.Status, "ACTIVE") {
+			continue
+		}
+		if result, ok := mysqlRemovedPlugins[step][strings.ToLower(plugin.Name)]; ok {
+			result.object = "plugin " + plugin.Name
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// mysqlNewReservedWords are the words that become reserved with each step
+var mysqlNewReservedWords = map[string][]string{
+	"8.0": {
+		"ARRAY", "CUBE", "CUME_DIST", "DENSE_RANK", "EMPTY", "EXCEPT", "FIRST_VALUE", "FUNCTION",
+		"GROUPING", "GROUPS", "INTERSECT", "JSON_TABLE", "LAG", "LAST_VALUE", "LATERAL", "LEAD",
+		"MEMBER", "NTH_VALUE", "NTILE", "OF", "OVER", "PERCENT_RANK", "RANK", "RECURSIVE",
+		"ROW", "ROW_NUMBER", "ROWS", "SYSTEM", "WINDOW",
+	},
+	"8.4": {"MANUAL", "PARALLEL", "QUALIFY", "TABLESAMPLE"},
+}
+
+func checkReservedWords(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	reserved := make(map[string]bool)
+	for _, word := range mysqlNewReservedWords[step] {
+		reserved[word] = true
+	}
+	var results []upgradeResult
+	report := func(object, name string) {
+		if reserved[strings.ToUpper(name)] {
+			results = append(results, upgradeResult{SeverityWarning, object,
+				fmt.Sprintf("%s is a reserved word in %s; queries must quote it with backticks", name, step)})
+		}
+	}
+	for _, table := range info.Tables {
+		report(table.Schema+"."+table.Name, table.Name)
+	}
+	for _, table := range tables {
+		for _, column := range table.structure.Columns {
+			report(table.name()+"."+column.Name, column.Name)
+		}
+	}
+	for _, routine := range info.Routines {
+		report(strings.ToLower(routine.Type)+" "+routine.Schema+"."+routine.Name, routine.Name)
+	}
+	return results
+}
+
+// storedSQL is the SQL text of a view, routine, trigger or event
+type storedSQL struct {
+	object string
+	text   string
+}
+
+func storedSQLObjects(info *DatabaseInfo) []storedSQL {
+	withDB := info.DBType == "postgres" && spansDatabases(info.Tables)
+	var objects []storedSQL
+	for _, table := range info.Tables {
+		if table.Type == "VIEW" || table.Type == "MATERIALIZED VIEW" {
+			name := table.Schema + "." + table.Name
+			if withDB {
+				name = qualifiedName(table.Database, table.Schema, table.Name)
+			}
+			objects = append(objects, storedSQL{strings.ToLower(table.Type) + " " + name, table.DDL})
+		}
+	}
+	for _, routine := range info.Routines {
+		objects = append(objects, storedSQL{strings.ToLower(routine.Type) + " " + qualifiedName(routine.Database, routine.Schema, routine.Name), routine.Definition})
+	}
+	for _, trigger := range info.Triggers {
+		objects = append(objects, storedSQL{"trigger " + trigger.Schema + "." + trigger.Name, trigger.Statement})
+	}
+	for _, event := range info.Events {
+		objects = append(objects, storedSQL{"event " + event.Schema + "." + event.Name, event.Body})
+	}
+	return objects
+}
+
+// removedFunctionRe matches calls of the functions removed in 8.0
+var removedFunctionRe = regexp.MustCompile(`(?i)\b(PASSWORD|ENCODE|DECODE|ENCRYPT|DES_ENCRYPT|DES_DECRYPT|GLENGTH|ASTEXT|ASBINARY|GEOMFROMTEXT|GEOMFROMWKB)\s*\(`)
+
+func checkRemovedFunctions(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "8.0" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, object := range storedSQLObjects(info) {
+		var functions []string
+		for _, m := range removedFunctionRe.FindAllStringSubmatch(object.text, -1) {
+			functions = append(functions, strings.ToUpper(m[1])+"()")
+		}
+		if len(functions) > 0 {
+			results = append(results, upgradeResult{SeverityError, object.object,
+				fmt.Sprintf("calls %s, removed in 8.0", strings.Join(uniqueSorted(functions), ", "))})
+		}
+	}
+	return results
+}
+
+var groupByRe = regexp.MustCompile(`(?i)\bGROUP\s+BY\b`)
+
+// groupByClause is a GROUP BY clause and whether its query block has an ORDER BY
+type groupByClause struct {
+	clause  string
+	ordered bool
+}
+
+// groupByClauses finds the GROUP BY clauses in SQL text. A clause ends at the
+// next clause keyword, and its query block at a closing parenthesis or semicolon.
+func groupByClauses(text string) []groupByClause {
+	var clauses []groupByClause
+	for _, loc := range groupByRe.FindAllStringIndex(text, -1) {
+		start := loc[1]
+		end, depth := -1, 0
+		ordered := false
+	scan:
+		for i := start; i < len(text); i++ {
+			switch c := text[i]; {
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					break scan
+				}
+				depth--
+			case c == ';' && depth == 0:
+				break scan
+			case depth == 0 && isWordStart(text, i):
+				word := strings.ToUpper(leadingWord(text[i:]))
+				switch word {
+				case "HAVING", "ORDER", "LIMIT", "WINDOW", "UNION", "INTO", "FOR", "LOCK":
+					if end < 0 {
+						end = i
+					}
+				}
+				if word == "ORDER" {
+					ordered = true
+					break scan
+				}
+				if word == "UNION" {
+					break scan
+				}
+			}
+		}
+		if end < 0 {
+			end = len(text)
+			if i := strings.IndexAny(text[start:], ");"); i >= 0 {
+				end = start + i
+			}
+		}
+		clauses = append(clauses, groupByClause{clause: text[start:end], ordered: ordered})
+	}
+	return clauses
+}
+
+func isWordStart(s string, i int) bool {
+	return isWordChar(s[i]) && (i == 0 || !isWordChar(s[i-1]))
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func leadingWord(s string) string {
+	i := 0
+	for i < len(s) && isWordChar(s[i]) {
+		i++
+	}
+	return s[:i]
+}
+
+var sortDirectionRe = regexp.MustCompile(`(?i)\b(ASC|DESC)\b`)
+
+func checkGroupBySorting(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "8.0" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, object := range storedSQLObjects(info) {
+		sorted, unordered := false, false
+		for _, clause := range groupByClauses(object.text) {
+			if sortDirectionRe.MatchString(clause.clause) {
+				sorted = true
+			} else if !clause.ordered {
+				unordered = true
+			}
+		}
+		if sorted {
+			results = append(results, upgradeResult{SeverityError, object.object,
+				"uses GROUP BY ... ASC/DESC, a syntax error in 8.0; use ORDER BY"})
+		}
+		if unordered {
+			results = append(results, upgradeResult{SeverityInfo, object.object,
+				"GROUP BY without ORDER BY; 8.0 no longer sorts grouped results, add ORDER BY if the order matters"})
+		}
+	}
+	return results
+}
+
+func checkNonNativePartitioning(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "8.0" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, table := range tables {
+		engine := table.info.Engine
+		if strings.Contains(strings.ToUpper(table.info.DDL), "PARTITION BY") &&
+			!strings.EqualFold(engine, "InnoDB") && !strings.EqualFold(engine, "ndbcluster") {
+			results = append(results, upgradeResult{SeverityError, table.name(),
+				fmt.Sprintf("partitioned %s table; 8.0 supports partitioning only for InnoDB and NDB", engine)})
+		}
+	}
+	return results
+}
+
+var zeroDateRe = regexp.MustCompile(`^'?0000-00-00`)
+
+func checkZeroDateDefaults(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, table := range tables {
+		for _, column := range table.structure.Columns {
+			if zeroDateRe.MatchString(column.Default) {
+				results = append(results, upgradeResult{SeverityWarning, table.name() + "." + column.Name,
+					fmt.Sprintf("%s column defaults to a zero date, rejected by the default sql_mode (NO_ZERO_DATE, strict mode) when the table is altered", column.Type)})
+			}
+		}
+	}
+	return results
+}
+
+func checkUTF8MB3(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	isUTF8MB3 := func(charset string) bool {
+		charset = strings.ToLower(charset)
+		return charset == "utf8" || charset == "utf8mb3"
+	}
+	for _, table := range tables {
+		var columns []string
+		for _, column := range table.structure.Columns {
+			if isUTF8MB3(column.Charset) {
+				columns = append(columns, column.Name)
+			}
+		}
+		sort.Strings(columns)
+		var message string
+		switch {
+		case isUTF8MB3(table.structure.Charset):
+			message = "table default character set is utf8mb3"
+			if len(columns) > 0 {
+				message += ", as are columns " + strings.Join(columns, ", ")
+			}
+		case len(columns) > 0:
+			message = "columns " + strings.Join(columns, ", ") + " use utf8mb3"
+		default:
+			continue
+		}
+		results = append(results, upgradeResult{SeverityWarning, table.name(),
+			message + "; utf8mb3 is deprecated and utf8 will become an alias of utf8mb4, convert to utf8mb4"})
+	}
+	return results
+}
+
+// postgresUpgradeTargets are the major versions the PostgreSQL checks know about
+var postgresUpgradeTargets = []string{"17", "18"}
+
+var postgresUpgradeRules = []upgradeRule{
+	{"removed-setting", checkRemovedSettings},
+	{"removed-extension", checkRemovedExtensions},
+	{"extension-update", checkExtensionUpdates},
+	{"extension-install", checkExtensionInstall},
+	{"incompatible-type", checkIncompatibleTypes},
+	{"unlogged-partitioned", checkUnloggedPartitioned},
+	{"logical-replication", checkLogicalReplication},
+	{"pg-stat-bgwriter", checkStatBgwriterColumns},
+	{"md5-password", checkMD5Passwords},
+	{"data-checksums", checkDataChecksums},
+}
+
+func checkPostgreSQLUpgrade(info *DatabaseInfo, target string) (*UpgradeReport, error) {
+	version, err := ParsePostgreSQLVersion(info.ConnectionInfo.Version)
+	if err != nil {
+		return nil, err
+	}
+	targetKnown := false
+	for _, known := range postgresUpgradeTargets {
+		if known == target {
+			targetKnown = true
+		}
+	}
+	if !targetKnown {
+		return nil, fmt.Errorf("unsupported PostgreSQL upgrade target '%s'. Valid values: %s",
+			target, strings.Join(postgresUpgradeTargets, ", "))
+	}
+	source := fmt.Sprintf("%d", version.Major)
+	if compareVersions(source, target) >= 0 {
+		return nil, fmt.Errorf("server is already at PostgreSQL %s", source)
+	}
+
+	// pg_upgrade goes straight to the target, so the checks of every major in between apply
+	var steps []string
+	for major := version.Major + 1; fmt.Sprintf("%d", major) != target; major++ {
+		steps = append(steps, fmt.Sprintf("%d", major))
+	}
+	steps = append(steps, target)
+
+	findings := runUpgradeRules(info, postgresUpgradeRules, steps, []Finding{})
+	return &UpgradeReport{Source: version.String(), Target: target, Findings: findings}, nil
+}
+
+// postgresSourceMajor returns the major version of the collected server
+func postgresSourceMajor(info *DatabaseInfo) int {
+	if version, err := ParsePostgreSQLVersion(info.ConnectionInfo.Version); err == nil {
+		return version.Major
+	}
+	return 0
+}
+
+// postgresRemovedSettings are the settings removed by each major version.
+// The new server does not start when one of them is in postgresql.conf.
+var postgresRemovedSettings = map[string][]string{
+	"17": {"db_user_namespace", "old_snapshot_threshold", "trace_recovery_messages"},
+}
+
+func checkRemovedSettings(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, variable := range info.Variables {
+		if !variable.IsModified {
+			continue
+		}
+		for _, removed := range postgresRemovedSettings[step] {
+			if variable.Name == removed {
+				results = append(results, upgradeResult{SeverityError, variable.Name,
+					fmt.Sprintf("removed in PostgreSQL %s but set (%s); remove it from the configuration", step, variable.Source)})
+			}
+		}
+	}
+	return results
+}
+
+// postgresRemovedExtensions are the contrib extensions removed by each major version
+var postgresRemovedExtensions = map[string][]string{
+	"17": {"adminpack", "old_snapshot"},
+}
+
+// extensionObject names an extension, with its database when several were collected
+func extensionObject(ext Extension) string {
+	if ext.Database != "" {
+		return "extension " + ext.Database + "." + ext.Name
+	}
+	return "extension " + ext.Name
+}
+
+func checkRemovedExtensions(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, ext := range info.Extensions {
+		for _, removed := range postgresRemovedExtensions[step] {
+			if ext.Name == removed {
+				results = append(results, upgradeResult{SeverityError, extensionObject(ext),
+					fmt.Sprintf("removed in PostgreSQL %s; DROP EXTENSION %s before the upgrade", step, ext.Name)})
+			}
+		}
+	}
+	return results
+}
+
+func checkExtensionUpdates(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, ext := range info.Extensions {
+		if ext.DefaultVersion != "" && ext.Version != ext.DefaultVersion {
+			results = append(results, upgradeResult{SeverityWarning, extensionObject(ext),
+				fmt.Sprintf("installed version %s is not the available version %s; run ALTER EXTENSION %s UPDATE",
+					ext.Version, ext.DefaultVersion, ext.Name)})
+		}
+	}
+	return results
+}
+
+// postgresContribExtensions ship with PostgreSQL, so pg_upgrade finds them in the new installation
+var postgresContribExtensions = map[string]bool{
+	"adminpack": true, "amcheck": true, "autoinc": true, "bloom": true, "btree_gin": true, "btree_gist": true,
+	"citext": true, "cube": true, "dblink": true, "dict_int": true, "dict_xsyn": true, "earthdistance": true,
+	"file_fdw": true, "fuzzystrmatch": true, "hstore": true, "insert_username": true, "intagg": true,
+	"intarray": true, "isn": true, "lo": true, "ltree": true, "moddatetime": true, "old_snapshot": true,
+	"pageinspect": true, "pg_buffercache": true, "pg_freespacemap": true, "pg_prewarm": true,
+	"pg_stat_statements": true, "pg_surgery": true, "pg_trgm": true, "pg_visibility": true,
+	"pg_walinspect": true, "pgcrypto": true, "pgrowlocks": true, "pgstattuple": true, "plperl": true,
+	"plperlu": true, "plpgsql": true, "plpython3u": true, "pltcl": true, "pltclu": true, "postgres_fdw": true,
+	"refint": true, "seg": true, "sslinfo": true, "tablefunc": true, "tcn": true, "tsm_system_rows": true,
+	"tsm_system_time": true, "unaccent": true, "uuid-ossp": true, "xml2": true,
+}
+
+func checkExtensionInstall(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, ext := range info.Extensions {
+		if !postgresContribExtensions[ext.Name] {
+			results = append(results, upgradeResult{SeverityInfo, extensionObject(ext),
+				"not part of PostgreSQL; install a build of it for the target version on the new server before running pg_upgrade"})
+		}
+	}
+	return results
+}
+
+// postgresIncompatibleTypes are the column types pg_upgrade cannot carry over, because
+// their values store OIDs or their format changed. regclass, regrole and regtype are allowed.
+var postgresIncompatibleTypes = map[string]string{
+	"regcollation":  "stores OIDs that pg_upgrade does not preserve",
+	"regconfig":     "stores OIDs that pg_upgrade does not preserve",
+	"regdictionary": "stores OIDs that pg_upgrade does not preserve",
+	"regnamespace":  "stores OIDs that pg_upgrade does not preserve",
+	"regoper":       "stores OIDs that pg_upgrade does not preserve",
+	"regoperator":   "stores OIDs that pg_upgrade does not preserve",
+	"regproc":       "stores OIDs that pg_upgrade does not preserve",
+	"regprocedure":  "stores OIDs that pg_upgrade does not preserve",
+	"abstime":       "was removed in PostgreSQL 12",
+	"reltime":       "was removed in PostgreSQL 12",
+	"tinterval":     "was removed in PostgreSQL 12",
+}
+
+func checkIncompatibleTypes(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	var results []upgradeResult
+	for _, table := range tables {
+		for _, column := range table.structure.Columns {
+			// Array columns are listed with their element type prefixed by an underscore
+			typeName := strings.TrimPrefix(strings.TrimSuffix(column.Type, "[]"), "_")
+			if reason, ok := postgresIncompatibleTypes[typeName]; ok {
+				results = append(results, upgradeResult{SeverityError, table.name() + "." + column.Name,
+					fmt.Sprintf("column type %s %s; pg_upgrade refuses to run, change the column type first", typeName, reason)})
+			}
+		}
+	}
+	return results
+}
+
+func checkUnloggedPartitioned(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "18" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, table := range tables {
+		if table.info.Unlogged && table.info.PartitionKey != "" {
+			results = append(results, upgradeResult{SeverityError, table.name(),
+				"unlogged partitioned table; PostgreSQL 18 rejects them (the setting never applied to the partitions), run ALTER TABLE ... SET LOGGED"})
+		}
+	}
+	return results
+}
+
+func checkLogicalReplication(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if info.ReplicationInfo == nil {
+		return []upgradeResult{{SeverityInfo, "replication",
+			"replication slots and subscriptions were not collected; run with -replication to check them"}}
+	}
+	var results []upgradeResult
+	// pg_upgrade migrates logical slots and subscription state only from PostgreSQL 17 on
+	migrated := postgresSourceMajor(info) >= 17
+	for _, slot := range info.ReplicationInfo.ReplicationSlots {
+		if slot.Type != "logical" {
+			continue
+		}
+		object := "slot " + slot.Name
+		switch {
+		case !migrated:
+			results = append(results, upgradeResult{SeverityWarning, object,
+				"pg_upgrade does not migrate logical replication slots from this version; recreate the slot and resynchronize its consumers"})
+		case slot.WALStatus == "lost":
+			results = append(results, upgradeResult{SeverityError, object,
+				"slot is invalidated (wal_status lost); pg_upgrade fails until it is dropped"})
+		case !slot.Active:
+			results = append(results, upgradeResult{SeverityWarning, object,
+				"inactive slot; pg_upgrade requires every logical slot to have consumed all WAL"})
+		}
+	}
+	if !migrated {
+		for _, sub := range info.ReplicationInfo.Subscriptions {
+			results = append(results, upgradeResult{SeverityWarning, "subscription " + databaseObjectName(sub.Database, sub.Name),
+				"pg_upgrade does not keep the table synchronization state of subscriptions from this version; refresh the subscription after the upgrade"})
+		}
+	}
+	return results
+}
+
+// statBgwriterColumnRe matches the pg_stat_bgwriter columns removed in PostgreSQL 17
+var statBgwriterColumnRe = regexp.MustCompile(`(?i)\b(checkpoints_timed|checkpoints_req|checkpoint_write_time|checkpoint_sync_time|buffers_checkpoint|buffers_backend|buffers_backend_fsync)\b`)
+
+func checkStatBgwriterColumns(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "17" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, object := range storedSQLObjects(info) {
+		if !strings.Contains(strings.ToLower(object.text), "pg_stat_bgwriter") || !statBgwriterColumnRe.MatchString(object.text) {
+			continue
+		}
+		// Views are restored by pg_upgrade; function bodies are only checked when called
+		severity := SeverityWarning
+		if strings.HasPrefix(object.object, "view ") || strings.HasPrefix(object.object, "materialized view ") {
+			severity = SeverityError
+		}
+		results = append(results, upgradeResult{severity, object.object,
+			"reads pg_stat_bgwriter columns moved to pg_stat_checkpointer or pg_stat_io in PostgreSQL 17"})
+	}
+	return results
+}
+
+func checkMD5Passwords(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "18" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, variable := range info.Variables {
+		if variable.Name == "password_encryption" && variable.CurrentValue == "md5" {
+			results = append(results, upgradeResult{SeverityWarning, variable.Name,
+				"MD5 passwords are deprecated in PostgreSQL 18; switch to scram-sha-256 and reset the passwords"})
+		}
+	}
+	return results
+}
+
+func checkDataChecksums(info *DatabaseInfo, tables []lintTable, step string) []upgradeResult {
+	if step != "18" {
+		return nil
+	}
+	var results []upgradeResult
+	for _, variable := range info.Variables {
+		if variable.Name == "data_checksums" && variable.CurrentValue == "off" {
+			results = append(results, upgradeResult{SeverityWarning, variable.Name,
+				"initdb enables data checksums by default in PostgreSQL 18 and pg_upgrade needs matching settings; run initdb with --no-data-checksums"})
+		}
+	}
+	return results
+}
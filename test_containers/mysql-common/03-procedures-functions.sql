@@ -136,4 +136,11 @@ BEGIN
     END IF;
 END //
 
+-- Scheduled events
+CREATE EVENT purge_old_logs
+ON SCHEDULE EVERY 1 DAY
+COMMENT 'Remove log entries older than 90 days'
+DO
+    DELETE FROM logs WHERE log_date < CURDATE() - INTERVAL 90 DAY //
+
 DELIMITER ;
\ No newline at end of file
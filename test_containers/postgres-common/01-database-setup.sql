@@ -5,6 +5,9 @@ CREATE USER testuser WITH PASSWORD 'testpass';
 CREATE USER readonly WITH PASSWORD 'readpass';
 CREATE USER admin WITH PASSWORD 'adminpass' CREATEDB CREATEROLE;
 
+-- Statement statistics for -workload (preloaded in docker-compose.yml)
+CREATE EXTENSION IF NOT EXISTS pg_stat_statements;
+
 -- Create second test database
 CREATE DATABASE testdb2 OWNER postgres;
 
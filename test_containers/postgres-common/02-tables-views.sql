@@ -31,6 +31,15 @@ CREATE INDEX idx_products_price ON products(price);
 -- Orders table
 CREATE TYPE order_status AS ENUM ('pending', 'processing', 'shipped', 'delivered', 'cancelled');
 
+-- Domain, composite and range types
+CREATE DOMAIN positive_amount AS NUMERIC(10,2) NOT NULL CHECK (VALUE >= 0);
+CREATE TYPE postal_address AS (
+    street TEXT,
+    city TEXT,
+    postal_code VARCHAR(10)
+);
+CREATE TYPE price_range AS RANGE (subtype = numeric);
+
 CREATE TABLE orders (
     id SERIAL PRIMARY KEY,
     user_id INTEGER NOT NULL REFERENCES users(id),
@@ -97,6 +106,13 @@ CREATE TABLE logs_2024 PARTITION OF logs
 CREATE TABLE logs_2025 PARTITION OF logs
     FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
 
+-- Standalone sequence
+CREATE SEQUENCE invoice_number_seq
+    AS BIGINT
+    INCREMENT BY 1
+    START WITH 1000
+    CACHE 10;
+
 -- Views
 CREATE VIEW active_users AS
     SELECT id, username, email, created_at
@@ -121,9 +137,22 @@ CREATE VIEW product_inventory AS
            END as stock_status
     FROM products;
 
+-- Materialized view
+CREATE MATERIALIZED VIEW daily_sales AS
+    SELECT order_date::date AS sales_date,
+           COUNT(*) AS order_count,
+           SUM(total_amount) AS total_sales
+    FROM orders
+    GROUP BY order_date::date;
+
+CREATE UNIQUE INDEX idx_daily_sales_date ON daily_sales(sales_date);
+
 -- Grant table access
 GRANT SELECT ON ALL TABLES IN SCHEMA public TO readonly;
 GRANT ALL ON ALL TABLES IN SCHEMA public TO testuser;
 GRANT ALL ON ALL SEQUENCES IN SCHEMA public TO testuser;
 GRANT ALL ON ALL TABLES IN SCHEMA public TO admin;
 GRANT ALL ON ALL SEQUENCES IN SCHEMA public TO admin;
+
+-- Logical replication publication (takes effect only with wal_level = logical)
+CREATE PUBLICATION orders_pub FOR TABLE orders, order_items WITH (publish = 'insert, update, delete');
@@ -96,3 +96,22 @@ CREATE TRIGGER before_user_update
     BEFORE UPDATE ON users
     FOR EACH ROW
     EXECUTE FUNCTION update_timestamp();
+
+-- Rule: keep products that are still in stock
+CREATE RULE protect_stocked_products AS
+    ON DELETE TO products
+    WHERE OLD.stock_quantity > 0
+    DO INSTEAD NOTHING;
+
+-- Event trigger: log DDL commands
+CREATE OR REPLACE FUNCTION log_ddl_command()
+RETURNS event_trigger AS $$
+BEGIN
+    RAISE NOTICE 'DDL command: %', tg_tag;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE EVENT TRIGGER log_table_ddl
+    ON ddl_command_end
+    WHEN TAG IN ('CREATE TABLE', 'ALTER TABLE')
+    EXECUTE FUNCTION log_ddl_command();
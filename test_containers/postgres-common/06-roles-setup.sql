@@ -23,3 +23,19 @@ GRANT app_admin TO developer;
 GRANT app_read TO readonly;
 GRANT app_write TO testuser;
 GRANT developer TO admin;
+
+-- Row-level security: analysts only see delivered orders
+ALTER TABLE orders ENABLE ROW LEVEL SECURITY;
+CREATE POLICY orders_admin_all ON orders
+    FOR ALL
+    TO app_admin
+    USING (true);
+CREATE POLICY orders_analyst_delivered ON orders
+    FOR SELECT
+    TO analyst
+    USING (status = 'delivered');
+
+-- Column-level and default privileges
+GRANT SELECT (id, username) ON users TO developer;
+GRANT USAGE ON TYPE order_status TO analyst;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO app_read;